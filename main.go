@@ -24,7 +24,9 @@ func runGame() error {
 	if err != nil {
 		return errors.New("failed to create game: " + err.Error())
 	}
-	g.Seed(123, 456)
+	if err := g.Seed(123, 456); err != nil {
+		return errors.New("failed to seed game: " + err.Error())
+	}
 	p1, err := truco.NewPlayer("player 1")
 	if err != nil {
 		return errors.New("failed to create player: " + err.Error())
@@ -41,42 +43,68 @@ func runGame() error {
 		return errors.New("failed to add player 2 to game: " + err.Error())
 	}
 
-	if err := g.Start(); err != nil {
+	g.Subscribe(printEvent)
+	if _, err := g.Start(); err != nil {
 		return errors.New("failed to start game: " + err.Error())
 	}
 
-	for i := -0; i < 20; i++ {
-		fmt.Printf("=================== HAND %d ===================\n", i)
-		fmt.Printf("manilha: %s\n", g.Manilha().Unicode())
-		for j := i; j <= 6; j++ {
-			cp := g.CurrentPlayer()
-			printCards(cp)
-			fmt.Printf("%s: playing card ( %s )\n", cp.Name(), cp.Cards()[0].Unicode())
-			if err := g.Play(cp, cp.Cards()[0]); err != nil {
+	for g.Running() {
+		if p := g.MaoDeOnze(); p != nil {
+			if _, err := g.AcceptMaoDeOnze(p); err != nil {
 				return err
 			}
-			if j%2 == 0 {
-				lp := g.LastPoint()
-				if lp == nil {
-					fmt.Println("point: draw")
-				} else {
-					fmt.Printf("point: %s\n", lp.Name())
-				}
-				fmt.Printf("---------------------- ROUND %d ----------------------\n", j/2)
-			}
+			continue
+		}
+		cp := g.CurrentPlayer()
+		printCards(cp)
+		if _, err := g.PlayPosition(cp, 0); err != nil {
+			return err
 		}
-		winner := g.Winner()
-		if winner == nil {
+	}
+	fmt.Printf("score: %v\n", g.Scores())
+	return nil
+}
+
+func printEvent(event truco.Event) {
+	switch e := event.(type) {
+	case truco.HandDealt:
+		fmt.Printf("=================== HAND %d ===================\n", e.Hand)
+		fmt.Printf("manilha: %s\n", e.Manilha.Unicode())
+	case truco.DeckCut:
+		fmt.Printf("%s: cutting the deck at %d\n", e.Player.Name(), e.Position)
+	case truco.CardPlayed:
+		fmt.Printf("%s: playing card ( %s )\n", e.Player.Name(), e.Card.Unicode())
+	case truco.RoundWon:
+		fmt.Printf("point: %s\n", e.Player.Name())
+		fmt.Printf("---------------------- ROUND %d ----------------------\n", e.Round)
+	case truco.RoundTied:
+		fmt.Println("point: draw")
+		fmt.Printf("---------------------- ROUND %d ----------------------\n", e.Round)
+	case truco.TrucoCalled:
+		fmt.Printf("%s: truco for %d points\n", e.Player.Name(), e.Value)
+	case truco.TrucoAccepted:
+		fmt.Printf("%s: accepted, hand worth %d points\n", e.Player.Name(), e.Value)
+	case truco.TrucoRefused:
+		fmt.Printf("%s: refused truco\n", e.Player.Name())
+	case truco.Folded:
+		fmt.Printf("%s: folded\n", e.Player.Name())
+	case truco.EnvidoScored:
+		fmt.Printf("envido: team %d, %d points\n", e.Team, e.Points)
+	case truco.FlorScored:
+		fmt.Printf("flor: team %d, %d points\n", e.Team, e.Points)
+	case truco.MaoDeOnzeAccepted:
+		fmt.Printf("%s: playing mão de onze\n", e.Player.Name())
+	case truco.MaoDeOnzeRefused:
+		fmt.Printf("%s: giving up mão de onze\n", e.Player.Name())
+	case truco.HandWon:
+		if e.Team == -1 {
 			fmt.Println("won: draw")
 		} else {
-			fmt.Printf("won: %s\n", winner.Name())
-		}
-		if !g.Running() {
-			fmt.Println("game finished")
-			break
+			fmt.Printf("won: team %d, %d points\n", e.Team, e.Points)
 		}
+	case truco.GameOver:
+		fmt.Printf("game finished, team %d won\n", e.Team)
 	}
-	return nil
 }
 
 func printCards(player *truco.Player) {
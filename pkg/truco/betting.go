@@ -0,0 +1,189 @@
+package truco
+
+// call is a truco call waiting for an answer from the other team
+type call struct {
+	// position of the player who made the call
+	position int
+	// betting level requested, index of the rule set stakes
+	stake int
+}
+
+// DefaultStakes returns the value of a hand for each betting level:
+// no call, truco, seis, nove and doze
+func DefaultStakes() []int {
+	return []int{1, 3, 6, 9, 12}
+}
+
+// Truco calls truco on the player's turn. If the other side has a pending call,
+// it accepts that call and raises it to the next value (seis, nove, doze)
+func (g *Game) Truco(player *Player) ([]Event, error) {
+	move := Move{Action: ActionTruco, Player: g.position(player)}
+	if !g.running {
+		return nil, ErrGameNotRunning
+	}
+	position := g.position(player)
+	if position == -1 {
+		return nil, ErrPlayerNotFound
+	}
+	if !g.hand().inHand(position) {
+		return nil, ErrNotInHand
+	}
+	h := g.hand()
+	if h.phase == phaseCut {
+		return nil, ErrCutPending
+	}
+	if h.maoDeOnze != -1 {
+		return nil, ErrTrucoInMaoDeOnze
+	}
+	if h.maoDeFerro {
+		return nil, ErrTrucoInMaoDeFerro
+	}
+	if h.florOpen() {
+		return nil, ErrFlorPending
+	}
+	if h.envidoOpen() {
+		return nil, ErrEnvidoPending
+	}
+
+	if h.call != nil {
+		if g.team(h.call.position) == g.team(position) {
+			return nil, ErrOwnCall
+		}
+		if h.call.stake+1 >= len(g.rules.Stakes) {
+			return nil, ErrCannotRaise
+		}
+		h.stake = h.call.stake
+		h.raisedBy = g.team(h.call.position)
+		h.call = &call{position: position, stake: h.stake + 1}
+		g.emit(TrucoCalled{Player: player, Value: g.rules.Stakes[h.call.stake]})
+		return g.record(move, nil)
+	}
+
+	if position != int(h.currentPlayer) {
+		return nil, ErrNotPlayerTurn
+	}
+	// the side that made the last accepted call has to wait for the other side to raise
+	if h.raisedBy == g.team(position) {
+		return nil, ErrOwnCall
+	}
+	if h.stake+1 >= len(g.rules.Stakes) {
+		return nil, ErrCannotRaise
+	}
+	h.call = &call{position: position, stake: h.stake + 1}
+	g.emit(TrucoCalled{Player: player, Value: g.rules.Stakes[h.call.stake]})
+	return g.record(move, nil)
+}
+
+// Accept accepts the pending call, the hand is now worth the called value. A
+// pending flor or envido call is answered before the truco call
+func (g *Game) Accept(player *Player) ([]Event, error) {
+	move := Move{Action: ActionAccept, Player: g.position(player)}
+	if err := g.checkAnswer(player); err != nil {
+		return nil, err
+	}
+	if g.hand().florOpen() {
+		return g.record(move, g.acceptFlor(player))
+	}
+	if g.hand().envidoOpen() {
+		return g.record(move, g.acceptEnvido(player))
+	}
+	h := g.hand()
+	h.stake = h.call.stake
+	h.raisedBy = g.team(h.call.position)
+	h.call = nil
+	g.emit(TrucoAccepted{Player: player, Value: g.rules.Stakes[h.stake]})
+	return g.record(move, nil)
+}
+
+// Refuse runs from the pending call, the caller wins the hand with the value it
+// had before the call
+func (g *Game) Refuse(player *Player) ([]Event, error) {
+	move := Move{Action: ActionRefuse, Player: g.position(player)}
+	if err := g.checkAnswer(player); err != nil {
+		return nil, err
+	}
+	if g.hand().florOpen() {
+		return g.record(move, g.refuseFlor(player))
+	}
+	if g.hand().envidoOpen() {
+		return g.record(move, g.refuseEnvido(player))
+	}
+	h := g.hand()
+	caller := g.team(h.call.position)
+	h.call = nil
+	g.emit(TrucoRefused{Player: player})
+	return g.record(move, g.endHand(caller))
+}
+
+// Fold gives up the hand ("correr"), the other side scores the hand value. If
+// the player is answering a call, the value before that call is scored
+func (g *Game) Fold(player *Player) ([]Event, error) {
+	if !g.running {
+		return nil, ErrGameNotRunning
+	}
+	position := g.position(player)
+	if position == -1 {
+		return nil, ErrPlayerNotFound
+	}
+	if !g.hand().inHand(position) {
+		return nil, ErrNotInHand
+	}
+	h := g.hand()
+	if h.phase == phaseCut {
+		return nil, ErrCutPending
+	}
+	if h.phase == phaseMaoDeOnze {
+		return nil, ErrMaoDeOnzePending
+	}
+	if h.florOpen() {
+		return nil, ErrFlorPending
+	}
+	if h.envidoOpen() {
+		return nil, ErrEnvidoPending
+	}
+	if h.call != nil {
+		if g.team(h.call.position) == g.team(position) {
+			return nil, ErrCallPending
+		}
+	} else if position != int(h.currentPlayer) {
+		return nil, ErrNotPlayerTurn
+	}
+	h.call = nil
+	g.emit(Folded{Player: player})
+	move := Move{Action: ActionFold, Player: position}
+	return g.record(move, g.endHand(g.team(position)^1))
+}
+
+// checkAnswer checks if the player can answer the pending call
+func (g *Game) checkAnswer(player *Player) error {
+	if !g.running {
+		return ErrGameNotRunning
+	}
+	position := g.position(player)
+	if position == -1 {
+		return ErrPlayerNotFound
+	}
+	if !g.hand().inHand(position) {
+		return ErrNotInHand
+	}
+	h := g.hand()
+	if h.phase == phaseCut {
+		return ErrCutPending
+	}
+	if h.florOpen() {
+		return nil
+	}
+	if h.envidoOpen() {
+		if !h.envido.pending {
+			return ErrEnvidoPending
+		}
+		return nil
+	}
+	if h.call == nil {
+		return ErrNoCallPending
+	}
+	if g.team(h.call.position) == g.team(position) {
+		return ErrOwnCall
+	}
+	return nil
+}
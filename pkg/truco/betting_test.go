@@ -0,0 +1,160 @@
+package truco
+
+import "testing"
+
+func TestTruco(t *testing.T) {
+	g := startedGame(t)
+	p1 := g.players[0]
+	p2 := g.players[1]
+
+	if _, err := g.Truco(p2); err != ErrNotPlayerTurn {
+		t.Errorf("expected error ErrNotPlayerTurn, instead got: %v", err)
+	}
+	if _, err := g.Truco(p1); err != nil {
+		t.Error("failed to call truco: " + err.Error())
+	}
+	if _, err := g.Play(p1, QueenSpades); err != ErrCallPending {
+		t.Errorf("expected error ErrCallPending, instead got: %v", err)
+	}
+	if _, err := g.Accept(p1); err != ErrOwnCall {
+		t.Errorf("expected error ErrOwnCall, instead got: %v", err)
+	}
+	if _, err := g.Accept(p2); err != nil {
+		t.Error("failed to accept truco: " + err.Error())
+	}
+	if g.HandValue() != 3 {
+		t.Errorf("expected hand value to be 3, instead got: %d", g.HandValue())
+	}
+	if _, err := g.Accept(p2); err != ErrNoCallPending {
+		t.Errorf("expected error ErrNoCallPending, instead got: %v", err)
+	}
+	// the side that called truco can't raise its own bet
+	if _, err := g.Truco(p1); err != ErrOwnCall {
+		t.Errorf("expected error ErrOwnCall, instead got: %v", err)
+	}
+	if _, err := g.Play(p1, QueenSpades); err != nil {
+		t.Error("failed to play card: " + err.Error())
+	}
+}
+
+func TestTrucoRaise(t *testing.T) {
+	g := startedGame(t)
+	p1 := g.players[0]
+	p2 := g.players[1]
+
+	// truco, seis, nove, doze
+	callers := []*Player{p1, p2, p1, p2}
+	for _, caller := range callers {
+		if _, err := g.Truco(caller); err != nil {
+			t.Errorf("failed to raise with %s: %s", caller.Name(), err.Error())
+		}
+	}
+	if g.HandValue() != 9 {
+		t.Errorf("expected hand value to be 9 before accepting doze, instead got: %d", g.HandValue())
+	}
+	if _, err := g.Truco(p1); err != ErrCannotRaise {
+		t.Errorf("expected error ErrCannotRaise, instead got: %v", err)
+	}
+	if _, err := g.Accept(p1); err != nil {
+		t.Error("failed to accept doze: " + err.Error())
+	}
+	if g.HandValue() != 12 {
+		t.Errorf("expected hand value to be 12, instead got: %d", g.HandValue())
+	}
+}
+
+func TestRefuse(t *testing.T) {
+	g := startedGame(t)
+	p1 := g.players[0]
+	p2 := g.players[1]
+
+	if _, err := g.Truco(p1); err != nil {
+		t.Error("failed to call truco: " + err.Error())
+	}
+	if _, err := g.Truco(p2); err != nil {
+		t.Error("failed to raise to seis: " + err.Error())
+	}
+	if _, err := g.Refuse(p1); err != nil {
+		t.Error("failed to refuse seis: " + err.Error())
+	}
+	if g.Scores()[1] != 3 {
+		t.Errorf("expected player 2 to score 3 points, instead got: %d", g.Scores()[1])
+	}
+	if len(g.hands) != 2 {
+		t.Errorf("expected a new hand to start, instead got %d hands", len(g.hands))
+	}
+	if len(p1.cards) != 3 || len(p2.cards) != 3 {
+		t.Error("players should have 3 cards in the new hand")
+	}
+	if g.HandValue() != 1 {
+		t.Errorf("expected new hand value to be 1, instead got: %d", g.HandValue())
+	}
+}
+
+func TestGameEndsAtTargetScore(t *testing.T) {
+	g := startedGame(t)
+	p1 := g.players[0]
+	p2 := g.players[1]
+
+	g.score[0] = 11
+	if _, err := g.Truco(p1); err != nil {
+		t.Error("failed to call truco: " + err.Error())
+	}
+	if _, err := g.Refuse(p2); err != nil {
+		t.Error("failed to refuse truco: " + err.Error())
+	}
+	if g.Running() {
+		t.Error("game should be over after player 1 reached 12 points")
+	}
+	if _, err := g.Truco(p1); err != ErrGameNotRunning {
+		t.Errorf("expected error ErrGameNotRunning, instead got: %v", err)
+	}
+}
+
+func TestFold(t *testing.T) {
+	g := startedGame(t)
+	p1 := g.players[0]
+	p2 := g.players[1]
+
+	if _, err := g.Fold(p2); err != ErrNotPlayerTurn {
+		t.Errorf("expected error ErrNotPlayerTurn, instead got: %v", err)
+	}
+	if _, err := g.Fold(p1); err != nil {
+		t.Error("failed to fold: " + err.Error())
+	}
+	if g.Scores()[1] != 1 {
+		t.Errorf("expected player 2 to score 1 point, instead got: %d", g.Scores()[1])
+	}
+	if len(g.hands) != 2 || len(p1.cards) != 3 {
+		t.Error("a new hand should have been dealt")
+	}
+}
+
+func TestFoldPendingCall(t *testing.T) {
+	g := startedGame(t)
+	p1 := g.players[0]
+	p2 := g.players[1]
+
+	if _, err := g.Truco(p1); err != nil {
+		t.Error("failed to call truco: " + err.Error())
+	}
+	if _, err := g.Accept(p2); err != nil {
+		t.Error("failed to accept truco: " + err.Error())
+	}
+	if _, err := g.Play(p1, QueenSpades); err != nil {
+		t.Error("failed to play card: " + err.Error())
+	}
+	if _, err := g.Truco(p2); err != nil {
+		t.Error("failed to raise to seis: " + err.Error())
+	}
+	if _, err := g.Fold(p2); err != ErrCallPending {
+		t.Errorf("expected error ErrCallPending, instead got: %v", err)
+	}
+	if _, err := g.Fold(p1); err != nil {
+		t.Error("failed to fold: " + err.Error())
+	}
+	// seis was never accepted, so the hand is worth the truco value
+	if g.Scores()[1] != 3 {
+		t.Errorf("expected player 2 to score 3 points, instead got: %d", g.Scores()[1])
+	}
+}
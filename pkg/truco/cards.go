@@ -3,6 +3,7 @@ package truco
 import (
 	"fmt"
 	"math/rand/v2"
+	"slices"
 	"strconv"
 )
 
@@ -21,6 +22,9 @@ const (
 	Five  = "5"
 	Six   = "6"
 	Seven = "7"
+	Eight = "8"
+	Nine  = "9"
+	Ten   = "A"
 	Jack  = "B"
 	Queen = "D"
 	King  = "E"
@@ -63,6 +67,18 @@ const (
 	SevenHearts   Card = Hearts + Seven   // "B7"
 	SevenDiamonds Card = Diamonds + Seven // "C7"
 	SevenClubs    Card = Clubs + Seven    // "D7"
+	EightSpades   Card = Spades + Eight   // "A8"
+	EightHearts   Card = Hearts + Eight   // "B8"
+	EightDiamonds Card = Diamonds + Eight // "C8"
+	EightClubs    Card = Clubs + Eight    // "D8"
+	NineSpades    Card = Spades + Nine    // "A9"
+	NineHearts    Card = Hearts + Nine    // "B9"
+	NineDiamonds  Card = Diamonds + Nine  // "C9"
+	NineClubs     Card = Clubs + Nine     // "D9"
+	TenSpades     Card = Spades + Ten     // "AA"
+	TenHearts     Card = Hearts + Ten     // "BA"
+	TenDiamonds   Card = Diamonds + Ten   // "CA"
+	TenClubs      Card = Clubs + Ten      // "DA"
 	JackSpades    Card = Spades + Jack    // "AB"
 	JackHearts    Card = Hearts + Jack    // "BB"
 	JackDiamonds  Card = Diamonds + Jack  // "CB"
@@ -75,6 +91,8 @@ const (
 	KingHearts    Card = Hearts + King    // "BE"
 	KingDiamonds  Card = Diamonds + King  // "CE"
 	KingClubs     Card = Clubs + King     // "DE"
+	// CardBack stands for a card whose value can't be seen
+	CardBack Card = Spades + "0" // "A0"
 )
 
 func DefaultDeck() []Card {
@@ -167,35 +185,80 @@ func DefaultDeckWeights() map[Card]int {
 	}
 }
 
-func nextCardID(id string) (string, error) {
-	nextIDs := map[string]string{
-		"1": "2",
-		"2": "3",
-		"3": "4",
-		"4": "5",
-		"5": "6",
-		"6": "7",
-		"7": "B",
-		"B": "D",
-		"D": "E",
-		"E": "1",
+// Deck is the composition of a deck, every rank is included in every suit
+type Deck struct {
+	// ranks from the lowest to the highest, the manilha is the rank after the
+	// vira and the lowest rank follows the highest
+	Ranks []string `json:"ranks"`
+	// suits included in the deck
+	Suits []string `json:"suits"`
+}
+
+// CleanDeck returns the 40 card deck ("baralho limpo") without the 8, 9 and 10
+func CleanDeck() Deck {
+	return Deck{
+		Ranks: []string{Four, Five, Six, Seven, Queen, Jack, King, Ace, Two, Three},
+		Suits: []string{Spades, Hearts, Diamonds, Clubs},
 	}
-	next, ok := nextIDs[id]
-	if !ok {
-		return "", fmt.Errorf("invalid card id: %s", id)
+}
+
+// DirtyDeck returns the 52 card deck ("baralho sujo") with the 8, 9 and 10
+// between the 7 and the queen
+func DirtyDeck() Deck {
+	return Deck{
+		Ranks: []string{Four, Five, Six, Seven, Eight, Nine, Ten, Queen, Jack, King, Ace, Two, Three},
+		Suits: []string{Spades, Hearts, Diamonds, Clubs},
 	}
+}
 
-	return next, nil
+// Cards returns the cards of the deck ordered by rank and suit, like
+// DefaultDeck
+func (d Deck) Cards() []Card {
+	ranks := slices.Clone(d.Ranks)
+	slices.Sort(ranks)
+	cards := make([]Card, 0, len(ranks)*len(d.Suits))
+	for _, rank := range ranks {
+		for _, suit := range d.Suits {
+			cards = append(cards, Card(suit+rank))
+		}
+	}
+	return cards
+}
+
+// Weights returns the weight of each card following the order of the ranks,
+// every suit has the same weight
+func (d Deck) Weights() DeckWeights {
+	weights := make(DeckWeights)
+	for i, rank := range d.Ranks {
+		for _, suit := range d.Suits {
+			weights[Card(suit+rank)] = i + 1
+		}
+	}
+	return weights
+}
+
+// nextRank returns the rank after the given one in strength order, the
+// highest rank is followed by the lowest
+func (d Deck) nextRank(rank string) (string, error) {
+	i := slices.Index(d.Ranks, rank)
+	if i == -1 {
+		return "", fmt.Errorf("invalid card id: %s", rank)
+	}
+	return d.Ranks[(i+1)%len(d.Ranks)], nil
 }
 
 func ShuffledDeck(seed1, seed2 uint64) []Card {
+	return shuffleDeck(DefaultDeck(), seed1, seed2)
+}
+
+// shuffleDeck shuffles the deck in place, random seeds are used if either seed
+// is zero
+func shuffleDeck(deck []Card, seed1, seed2 uint64) []Card {
 	if seed1 == 0 || seed2 == 0 {
 		seed1 = rand.Uint64()
 		seed2 = rand.Uint64()
 	}
 	r := rand.New(rand.NewPCG(seed1, seed2))
-
-	deck := DefaultDeck()
 	r.Shuffle(len(deck), func(i, j int) {
 		deck[i], deck[j] = deck[j], deck[i]
 	})
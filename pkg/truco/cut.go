@@ -0,0 +1,102 @@
+package truco
+
+import "slices"
+
+// CutChoice is an optional request of the player cutting the deck
+type CutChoice int
+
+const (
+	// CutBater keeps the card at the cut as the card turned up to choose the
+	// manilhas ("bater")
+	CutBater CutChoice = iota
+	// CutFromBottom asks the dealer to deal from the bottom of the deck
+	CutFromBottom
+)
+
+// cutChoiceNames are the names of the cut choices in saved games
+var cutChoiceNames = map[CutChoice]string{
+	CutBater:      "bater",
+	CutFromBottom: "fromBottom",
+}
+
+func (c CutChoice) MarshalText() ([]byte, error) {
+	return marshalName(cutChoiceNames, c, ErrUnknownName)
+}
+
+func (c *CutChoice) UnmarshalText(text []byte) error {
+	return unmarshalName(cutChoiceNames, text, c, ErrUnknownName)
+}
+
+// SetCut makes the player before the dealer cut the deck before each hand is
+// dealt, cards can only be played after the cut
+func (g *Game) SetCut(enabled bool) error {
+	if g.running {
+		return ErrGameRunning
+	}
+	g.cut = enabled
+	return nil
+}
+
+// Cutter returns the player who has to cut the deck, nil if the hand was
+// already dealt
+func (g *Game) Cutter() *Player {
+	if !g.running || g.hand().phase != phaseCut {
+		return nil
+	}
+	return g.players[g.cutter()]
+}
+
+// cutter returns the position of the player before the dealer
+func (g *Game) cutter() int {
+	return (g.hand().dealer + len(g.players) - 1) % len(g.players)
+}
+
+// Cut lifts the first cards of the deck up to the position and puts them under
+// the rest, then the hand is dealt. The position is between 1 and the number
+// of cards minus 1
+func (g *Game) Cut(player *Player, position int, choices ...CutChoice) ([]Event, error) {
+	if !g.running {
+		return nil, ErrGameNotRunning
+	}
+	cutter := g.position(player)
+	if cutter == -1 {
+		return nil, ErrPlayerNotFound
+	}
+	h := g.hand()
+	if h.phase != phaseCut {
+		return nil, ErrNoCut
+	}
+	if cutter != g.cutter() {
+		return nil, ErrNotCutter
+	}
+	if position < 1 || position >= len(h.deck) {
+		return nil, ErrInvalidCutPosition
+	}
+	bater, fromBottom := false, false
+	for _, choice := range choices {
+		switch choice {
+		case CutBater:
+			if g.rules.Manilha == ManilhaFixed {
+				return nil, ErrBaterWithoutVira
+			}
+			bater = true
+		case CutFromBottom:
+			fromBottom = true
+		}
+	}
+
+	cutCard := h.deck[position-1]
+	h.deck = slices.Concat(h.deck[position:], h.deck[:position])
+	if fromBottom {
+		slices.Reverse(h.deck)
+	}
+	if bater {
+		// the cut card goes on top to be turned up
+		i := slices.Index(h.deck, cutCard)
+		h.deck = slices.Insert(slices.Delete(h.deck, i, i+1), 0, cutCard)
+	}
+	h.phase = phasePlaying
+	g.emit(DeckCut{Player: player, Position: position, Bater: bater, FromBottom: fromBottom})
+	move := Move{Action: ActionCut, Player: g.position(player), Position: position, Choices: slices.Clone(choices)}
+	return g.record(move, g.deal())
+}
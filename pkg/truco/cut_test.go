@@ -0,0 +1,94 @@
+package truco
+
+import "testing"
+
+// cutGame returns a started game waiting for player 1 to cut the deck
+func cutGame(t *testing.T) *Game {
+	return startedGame(t, WithCut(true))
+}
+
+func TestCut(t *testing.T) {
+	g := cutGame(t)
+	p1 := g.players[0]
+	p2 := g.players[1]
+
+	if g.Cutter() != p1 {
+		t.Fatal("player 1 should be cutting the deck")
+	}
+	if len(p1.Cards()) != 0 {
+		t.Error("cards should not be dealt before the cut")
+	}
+	if _, err := g.Truco(p1); err != ErrCutPending {
+		t.Errorf("expected error ErrCutPending, instead got: %v", err)
+	}
+	if _, err := g.Cut(p2, 3); err != ErrNotCutter {
+		t.Errorf("expected error ErrNotCutter, instead got: %v", err)
+	}
+	if _, err := g.Cut(p1, 40); err != ErrInvalidCutPosition {
+		t.Errorf("expected error ErrInvalidCutPosition, instead got: %v", err)
+	}
+	events, err := g.Cut(p1, 3)
+	if err != nil {
+		t.Fatal("failed to cut the deck: " + err.Error())
+	}
+	checkEvents(t, events, []Event{DeckCut{Player: p1, Position: 3}, HandDealt{Hand: 0, Dealer: p2, Manilha: g.Manilha()}})
+	if _, err := g.Cut(p1, 3); err != ErrNoCut {
+		t.Errorf("expected error ErrNoCut, instead got: %v", err)
+	}
+	if g.Cutter() != nil {
+		t.Error("nobody should be cutting after the deal")
+	}
+	// B3 AD BD are moved under the rest of the deck
+	if g.Manilha() != ThreeDiamonds {
+		t.Error("wrong vira, expected C3, instead got: " + string(g.Manilha()))
+	}
+	if p1.cards[0] != SevenClubs || p2.cards[0] != FiveClubs {
+		t.Errorf("wrong cards after the cut, instead got: %v and %v", p1.cards, p2.cards)
+	}
+	if _, err := g.Play(p1, SevenClubs); err != nil {
+		t.Error("failed to play card: " + err.Error())
+	}
+}
+
+func TestCutBater(t *testing.T) {
+	g := cutGame(t)
+	p1 := g.players[0]
+
+	events, err := g.Cut(p1, 3, CutBater)
+	if err != nil {
+		t.Fatal("failed to cut the deck: " + err.Error())
+	}
+	checkEvents(t, events, []Event{DeckCut{Player: p1, Position: 3, Bater: true}, HandDealt{Hand: 0, Dealer: g.players[1], Manilha: QueenHearts}})
+	if g.Manilha() != QueenHearts {
+		t.Error("the cut card should be the vira, expected BD, instead got: " + string(g.Manilha()))
+	}
+	// the queen is followed by the jack
+	if g.hand().deckWeights[JackSpades] != DefaultDeckWeights()[JackSpades]+13 {
+		t.Error("jacks should be the manilhas")
+	}
+	if p1.cards[0] != ThreeDiamonds {
+		t.Error("wrong card for player, expected C3, instead got: " + string(p1.cards[0]))
+	}
+}
+
+func TestCutFromBottom(t *testing.T) {
+	g := cutGame(t)
+	p1 := g.players[0]
+
+	deck := append([]Card(nil), g.hand().deck...)
+	if _, err := g.Cut(p1, 3, CutFromBottom); err != nil {
+		t.Fatal("failed to cut the deck: " + err.Error())
+	}
+	// the bottom card of the cut deck is the last card lifted
+	if g.Manilha() != deck[2] {
+		t.Error("wrong vira, expected BD, instead got: " + string(g.Manilha()))
+	}
+	if p1.cards[0] != deck[1] || p1.cards[1] != deck[0] {
+		t.Errorf("cards should be dealt from the bottom, instead got: %v", p1.cards)
+	}
+
+	mineiro := startedGame(t, WithVariant(Mineiro), WithCut(true))
+	if _, err := mineiro.Cut(mineiro.players[0], 3, CutBater); err != ErrBaterWithoutVira {
+		t.Errorf("expected error ErrBaterWithoutVira, instead got: %v", err)
+	}
+}
@@ -0,0 +1,336 @@
+package truco
+
+import (
+	"slices"
+	"strconv"
+)
+
+// EnvidoCall is a bet on the envido points of the players, settled in the
+// first round alongside the card play
+type EnvidoCall int
+
+const (
+	// Envido is worth 2 points, it can be called twice in a row (envido envido)
+	Envido EnvidoCall = iota
+	// RealEnvido is worth 3 points
+	RealEnvido
+	// FaltaEnvido is worth the points the leading team needs to win the game
+	FaltaEnvido
+)
+
+// envidoCallNames are the names of the envido calls in saved games
+var envidoCallNames = map[EnvidoCall]string{
+	Envido:      "envido",
+	RealEnvido:  "realEnvido",
+	FaltaEnvido: "faltaEnvido",
+}
+
+func (c EnvidoCall) MarshalText() ([]byte, error) {
+	return marshalName(envidoCallNames, c, ErrUnknownName)
+}
+
+func (c *EnvidoCall) UnmarshalText(text []byte) error {
+	return unmarshalName(envidoCallNames, text, c, ErrUnknownName)
+}
+
+// EnvidoAnnouncement is what a player said after the envido was accepted
+type EnvidoAnnouncement struct {
+	Player *Player
+	// envido points announced ("tengo"), 0 if the player conceded
+	Points int
+	// the player said "son buenas" instead of showing their points
+	Conceded bool
+}
+
+// envido is the state of the envido bet in a hand
+type envido struct {
+	// calls made so far, in order
+	calls []EnvidoCall
+	// position of the player who made the last call
+	position int
+	// the last call is waiting for an answer
+	pending bool
+	// what each player said after the bet was accepted, in turn order
+	announcements []EnvidoAnnouncement
+	// position of the player with the best announced points, -1 = none
+	best int
+	// best announced points
+	bestPoints int
+	// the bet was settled and can't be called again in the hand
+	done bool
+}
+
+// CallEnvido calls envido on the player's turn, before they play their first
+// card. If the other team has a pending envido call, it raises that call. A
+// player answering a truco call in the first round can call envido first
+func (g *Game) CallEnvido(player *Player, bet EnvidoCall) ([]Event, error) {
+	if !g.running {
+		return nil, ErrGameNotRunning
+	}
+	position := g.position(player)
+	if position == -1 {
+		return nil, ErrPlayerNotFound
+	}
+	if !g.hand().inHand(position) {
+		return nil, ErrNotInHand
+	}
+	if !g.rules.Envido {
+		return nil, ErrEnvidoNotAllowed
+	}
+	h := g.hand()
+	if h.phase == phaseCut {
+		return nil, ErrCutPending
+	}
+	if h.envido != nil {
+		if !h.envido.pending {
+			return nil, ErrEnvidoClosed
+		}
+		if g.team(h.envido.position) == g.team(position) {
+			return nil, ErrOwnCall
+		}
+	} else if err := g.checkEnvidoOpen(position); err != nil {
+		return nil, err
+	}
+
+	var calls []EnvidoCall
+	if h.envido != nil {
+		calls = h.envido.calls
+	}
+	if len(g.rules.EnvidoCalls) != 0 && !slices.Contains(g.rules.EnvidoCalls, bet) {
+		return nil, ErrInvalidEnvidoCall
+	}
+	if !validEnvidoCall(calls, bet) {
+		return nil, ErrInvalidEnvidoCall
+	}
+	if h.envido == nil {
+		h.envido = &envido{best: -1}
+	}
+	h.envido.calls = append(h.envido.calls, bet)
+	h.envido.position = position
+	h.envido.pending = true
+	g.emit(EnvidoCalled{Player: player, Call: bet})
+	move := Move{Action: ActionCallEnvido, Player: g.position(player), Envido: bet}
+	return g.record(move, nil)
+}
+
+// checkEnvidoOpen checks if the player can start the envido bet
+func (g *Game) checkEnvidoOpen(position int) error {
+	h := g.hand()
+	// truco was already accepted
+	if h.round != 0 || h.stake != 0 {
+		return ErrEnvidoClosed
+	}
+	for _, c := range h.pile {
+		if c.position == position {
+			return ErrEnvidoClosed
+		}
+	}
+	if h.call != nil {
+		if g.team(h.call.position) == g.team(position) {
+			return ErrCallPending
+		}
+		return nil
+	}
+	if position != int(h.currentPlayer) {
+		return ErrNotPlayerTurn
+	}
+	return nil
+}
+
+// validEnvidoCall returns true if the bet can follow the previous calls: envido
+// at most twice and before real envido, real envido once, and falta envido last
+func validEnvidoCall(calls []EnvidoCall, bet EnvidoCall) bool {
+	count := make(map[EnvidoCall]int)
+	for _, c := range calls {
+		count[c] += 1
+	}
+	if count[FaltaEnvido] != 0 {
+		return false
+	}
+	switch bet {
+	case Envido:
+		return count[Envido] < 2 && count[RealEnvido] == 0
+	case RealEnvido:
+		return count[RealEnvido] == 0
+	case FaltaEnvido:
+		return true
+	}
+	return false
+}
+
+func (g *Game) acceptEnvido(player *Player) error {
+	position := g.position(player)
+	if g.team(g.hand().envido.position) == g.team(position) {
+		return ErrOwnCall
+	}
+	g.hand().envido.pending = false
+	g.emit(EnvidoAccepted{Player: player})
+	return nil
+}
+
+// refuseEnvido gives the caller the value of the calls before the last one, or
+// 1 point if only one call was made
+func (g *Game) refuseEnvido(player *Player) error {
+	e := g.hand().envido
+	position := g.position(player)
+	if g.team(e.position) == g.team(position) {
+		return ErrOwnCall
+	}
+	e.pending = false
+	e.done = true
+	g.emit(EnvidoRefused{Player: player})
+	return g.scoreEnvido(g.team(e.position), g.envidoValue(e.calls[:len(e.calls)-1]))
+}
+
+// AnnounceEnvido shows the player's envido points ("tengo", "son mejores").
+// After the envido is accepted, every player of the hand speaks in turn order
+// starting with the hand leader, the first one announces and the others only
+// announce if they beat the best points so far, ties go to the first to speak
+func (g *Game) AnnounceEnvido(player *Player) ([]Event, error) {
+	position, err := g.checkEnvidoSpeaker(player)
+	if err != nil {
+		return nil, err
+	}
+	e := g.hand().envido
+	points := g.envidoPoints(position)
+	if e.best != -1 && points <= e.bestPoints {
+		return nil, ErrEnvidoNotBetter
+	}
+	e.best = position
+	e.bestPoints = points
+	e.announcements = append(e.announcements, EnvidoAnnouncement{Player: player, Points: points})
+	g.emit(EnvidoAnnounced{Player: player, Points: points})
+	move := Move{Action: ActionAnnounceEnvido, Player: g.position(player)}
+	return g.record(move, g.settleEnvido())
+}
+
+// ConcedeEnvido says "son buenas", the player doesn't show their points
+func (g *Game) ConcedeEnvido(player *Player) ([]Event, error) {
+	if _, err := g.checkEnvidoSpeaker(player); err != nil {
+		return nil, err
+	}
+	e := g.hand().envido
+	if e.best == -1 {
+		return nil, ErrEnvidoFirstAnnouncement
+	}
+	e.announcements = append(e.announcements, EnvidoAnnouncement{Player: player, Conceded: true})
+	g.emit(EnvidoAnnounced{Player: player, Conceded: true})
+	move := Move{Action: ActionConcedeEnvido, Player: g.position(player)}
+	return g.record(move, g.settleEnvido())
+}
+
+// EnvidoAnnouncements returns what each player said about their envido points
+// in the current hand
+func (g *Game) EnvidoAnnouncements() []EnvidoAnnouncement {
+	if g.hand().envido == nil {
+		return nil
+	}
+	announcements := make([]EnvidoAnnouncement, len(g.hand().envido.announcements))
+	copy(announcements, g.hand().envido.announcements)
+	return announcements
+}
+
+func (g *Game) checkEnvidoSpeaker(player *Player) (int, error) {
+	if !g.running {
+		return -1, ErrGameNotRunning
+	}
+	position := g.position(player)
+	if position == -1 {
+		return -1, ErrPlayerNotFound
+	}
+	h := g.hand()
+	if h.envido == nil || h.envido.pending || h.envido.done {
+		return -1, ErrNoEnvidoAnnouncement
+	}
+	if h.seats[len(h.envido.announcements)] != position {
+		return -1, ErrNotPlayerTurn
+	}
+	return position, nil
+}
+
+// settleEnvido gives the accepted value to the team with the best points once
+// every player has spoken
+func (g *Game) settleEnvido() error {
+	h := g.hand()
+	e := h.envido
+	if len(e.announcements) != len(h.seats) {
+		return nil
+	}
+	e.done = true
+	return g.scoreEnvido(g.team(e.best), g.envidoValue(e.calls))
+}
+
+func (g *Game) scoreEnvido(team, points int) error {
+	g.emit(EnvidoScored{Team: team, Points: points})
+	return g.addPoints(team, points)
+}
+
+// envidoValue returns how many points the calls are worth, falta envido is
+// worth what the leading team needs to win and no calls are worth 1 point
+func (g *Game) envidoValue(calls []EnvidoCall) int {
+	if len(calls) == 0 {
+		return 1
+	}
+	value := 0
+	for _, c := range calls {
+		switch c {
+		case Envido:
+			value += 2
+		case RealEnvido:
+			value += 3
+		case FaltaEnvido:
+			return g.faltaEnvido()
+		}
+	}
+	return value
+}
+
+// faltaEnvido returns the points the leading team needs to win the game
+func (g *Game) faltaEnvido() int {
+	leader := 0
+	for _, score := range g.score {
+		leader = max(leader, score)
+	}
+	return g.rules.TargetScore - leader
+}
+
+// envidoOpen returns true if the envido bet was called and not settled yet
+func (h *Hand) envidoOpen() bool {
+	return h.envido != nil && !h.envido.done
+}
+
+// envidoPoints returns the envido points of the player in the position,
+// counting the piezas of the muestra
+func (g *Game) envidoPoints(position int) int {
+	player := g.players[position]
+	if points := piezaEnvido(player.dealt, g.hand().piezas); points != -1 {
+		return points
+	}
+	return player.EnvidoPoints()
+}
+
+// EnvidoPoints returns the envido points of the cards dealt to the player: 20
+// plus the value of the best two cards of the same suit, or the value of the
+// highest card if they are all of different suits. Jacks, queens and kings are
+// worth zero
+func (p *Player) EnvidoPoints() int {
+	points := 0
+	for i, c := range p.dealt {
+		points = max(points, envidoValue(c))
+		for _, other := range p.dealt[i+1:] {
+			if c[0] == other[0] {
+				points = max(points, 20+envidoValue(c)+envidoValue(other))
+			}
+		}
+	}
+	return points
+}
+
+// envidoValue returns the value of the card for the envido
+func envidoValue(card Card) int {
+	value, err := strconv.Atoi(string(card[1]))
+	if err != nil || value > 7 {
+		return 0
+	}
+	return value
+}
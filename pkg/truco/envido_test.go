@@ -0,0 +1,152 @@
+package truco
+
+import "testing"
+
+func TestEnvidoPoints(t *testing.T) {
+	p, err := NewPlayer("player 1")
+	if err != nil {
+		t.Fatal("failed to create player: " + err.Error())
+	}
+	hands := map[int][]Card{
+		33: {SevenSpades, SixSpades, AceHearts},
+		20: {KingHearts, JackHearts, FiveClubs},
+		7:  {AceSpades, TwoHearts, SevenClubs},
+		0:  {JackClubs, QueenDiamonds, KingHearts},
+		27: {SevenDiamonds, QueenDiamonds, FiveClubs},
+	}
+	for expected, cards := range hands {
+		p.dealt = cards
+		if p.EnvidoPoints() != expected {
+			t.Errorf("cards %v: expected %d envido points, instead got: %d", cards, expected, p.EnvidoPoints())
+		}
+	}
+}
+
+func TestEnvido(t *testing.T) {
+	g := argentinoGame(t)
+	p1 := g.players[0]
+	p2 := g.players[1]
+
+	// player 1 has 3 and queen of hearts (23), player 2 only a seven (7)
+	if _, err := g.CallEnvido(p2, Envido); err != ErrNotPlayerTurn {
+		t.Errorf("expected error ErrNotPlayerTurn, instead got: %v", err)
+	}
+	if _, err := g.CallEnvido(p1, Envido); err != nil {
+		t.Error("failed to call envido: " + err.Error())
+	}
+	if _, err := g.Play(p1, QueenSpades); err != ErrEnvidoPending {
+		t.Errorf("expected error ErrEnvidoPending, instead got: %v", err)
+	}
+	if _, err := g.CallEnvido(p1, RealEnvido); err != ErrOwnCall {
+		t.Errorf("expected error ErrOwnCall, instead got: %v", err)
+	}
+	if _, err := g.CallEnvido(p2, RealEnvido); err != nil {
+		t.Error("failed to raise to real envido: " + err.Error())
+	}
+	if _, err := g.CallEnvido(p1, Envido); err != ErrInvalidEnvidoCall {
+		t.Errorf("expected error ErrInvalidEnvidoCall, instead got: %v", err)
+	}
+	if _, err := g.Accept(p1); err != nil {
+		t.Error("failed to accept real envido: " + err.Error())
+	}
+	if _, err := g.AnnounceEnvido(p2); err != ErrNotPlayerTurn {
+		t.Errorf("expected error ErrNotPlayerTurn, instead got: %v", err)
+	}
+	if _, err := g.ConcedeEnvido(p1); err != ErrEnvidoFirstAnnouncement {
+		t.Errorf("expected error ErrEnvidoFirstAnnouncement, instead got: %v", err)
+	}
+	if _, err := g.AnnounceEnvido(p1); err != nil {
+		t.Error("failed to announce envido: " + err.Error())
+	}
+	if _, err := g.AnnounceEnvido(p2); err != ErrEnvidoNotBetter {
+		t.Errorf("expected error ErrEnvidoNotBetter, instead got: %v", err)
+	}
+	if _, err := g.ConcedeEnvido(p2); err != nil {
+		t.Error("failed to concede envido: " + err.Error())
+	}
+	announcements := g.EnvidoAnnouncements()
+	if len(announcements) != 2 || announcements[0].Points != 23 || !announcements[1].Conceded {
+		t.Errorf("wrong envido announcements: %v", announcements)
+	}
+	// envido (2) and real envido (3)
+	if g.Scores()[0] != 5 {
+		t.Errorf("expected player 1 to score 5 points, instead got: %d", g.Scores()[0])
+	}
+	if _, err := g.CallEnvido(p1, Envido); err != ErrEnvidoClosed {
+		t.Errorf("expected error ErrEnvidoClosed, instead got: %v", err)
+	}
+	if _, err := g.Play(p1, QueenSpades); err != nil {
+		t.Error("failed to play card: " + err.Error())
+	}
+}
+
+func TestRefuseEnvido(t *testing.T) {
+	g := argentinoGame(t)
+	p1 := g.players[0]
+	p2 := g.players[1]
+
+	if _, err := g.Play(p1, QueenSpades); err != nil {
+		t.Error("failed to play card: " + err.Error())
+	}
+	// player 2 hasn't played yet, so they can still call envido
+	if _, err := g.CallEnvido(p2, Envido); err != nil {
+		t.Error("failed to call envido: " + err.Error())
+	}
+	if _, err := g.CallEnvido(p1, Envido); err != nil {
+		t.Error("failed to raise to envido envido: " + err.Error())
+	}
+	if _, err := g.Refuse(p2); err != nil {
+		t.Error("failed to refuse envido: " + err.Error())
+	}
+	if g.Scores()[0] != 2 {
+		t.Errorf("expected player 1 to score 2 points, instead got: %d", g.Scores()[0])
+	}
+	if _, err := g.Play(p2, SevenClubs); err != nil {
+		t.Error("failed to play card: " + err.Error())
+	}
+	if _, err := g.CallEnvido(g.CurrentPlayer(), Envido); err != ErrEnvidoClosed {
+		t.Errorf("expected error ErrEnvidoClosed, instead got: %v", err)
+	}
+}
+
+func TestEnvidoBeforeTruco(t *testing.T) {
+	g := argentinoGame(t)
+	p1 := g.players[0]
+	p2 := g.players[1]
+
+	if _, err := g.Truco(p1); err != nil {
+		t.Error("failed to call truco: " + err.Error())
+	}
+	// the envido goes first
+	if _, err := g.CallEnvido(p2, FaltaEnvido); err != nil {
+		t.Error("failed to call falta envido: " + err.Error())
+	}
+	if _, err := g.Accept(p1); err != nil {
+		t.Error("failed to accept falta envido: " + err.Error())
+	}
+	if _, err := g.Accept(p2); err != ErrEnvidoPending {
+		t.Errorf("expected error ErrEnvidoPending, instead got: %v", err)
+	}
+	if _, err := g.AnnounceEnvido(p1); err != nil {
+		t.Error("failed to announce envido: " + err.Error())
+	}
+	if _, err := g.ConcedeEnvido(p2); err != nil {
+		t.Error("failed to concede envido: " + err.Error())
+	}
+	if g.Scores()[0] != 30 || g.Running() {
+		t.Errorf("player 1 should have won the game with the falta envido, score: %v", g.Scores())
+	}
+}
+
+func TestEnvidoNotAllowed(t *testing.T) {
+	g := startedGame(t)
+	if _, err := g.CallEnvido(g.players[0], Envido); err != ErrEnvidoNotAllowed {
+		t.Errorf("expected error ErrEnvidoNotAllowed, instead got: %v", err)
+	}
+}
+
+// argentinoGame returns a started Truco Argentino game where player 1 has
+// 3♥ Q♠ Q♥ and player 2 has 3♦ 7♣ A♠
+func argentinoGame(t *testing.T) *Game {
+	return startedGame(t, WithVariant(Argentino))
+}
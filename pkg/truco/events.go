@@ -0,0 +1,198 @@
+package truco
+
+// Event is something that happened in the game, it is one of the event types
+// below
+type Event interface {
+	event()
+}
+
+// HandDealt is sent when the cards of a hand are dealt
+type HandDealt struct {
+	// number of the hand in the game, starting at 0
+	Hand   int
+	Dealer *Player
+	// card turned up to choose the manilhas, empty when they are fixed
+	Manilha Card
+}
+
+// DeckCut is sent when the player before the dealer cuts the deck, a
+// HandDealt event follows
+type DeckCut struct {
+	Player *Player
+	// number of cards lifted from the top of the deck
+	Position   int
+	Bater      bool
+	FromBottom bool
+}
+
+// CardPlayed is sent every time a player plays a card
+type CardPlayed struct {
+	Player *Player
+	// CardBack if the card was played face down
+	Card     Card
+	FaceDown bool
+}
+
+// RoundWon is sent when a team wins a round
+type RoundWon struct {
+	// number of the round in the hand, starting at 0
+	Round int
+	// player who played the highest card
+	Player *Player
+	Team   int
+}
+
+// RoundTied is sent when both teams played the highest card of a round
+type RoundTied struct {
+	// number of the round in the hand, starting at 0
+	Round int
+}
+
+// HandWon is sent when a hand is over, Team is -1 and Points is 0 if nobody
+// scored the hand
+type HandWon struct {
+	Team   int
+	Points int
+}
+
+// TrucoCalled is sent when a player calls truco or raises a call
+type TrucoCalled struct {
+	Player *Player
+	// value of the hand if the call is accepted
+	Value int
+}
+
+// TrucoAccepted is sent when a truco call is accepted
+type TrucoAccepted struct {
+	Player *Player
+	// value of the hand from now on
+	Value int
+}
+
+// TrucoRefused is sent when a player runs from a truco call, a HandWon event
+// follows
+type TrucoRefused struct {
+	Player *Player
+}
+
+// Folded is sent when a player gives up the hand, a HandWon event follows
+type Folded struct {
+	Player *Player
+}
+
+// EnvidoCalled is sent when a player calls or raises the envido
+type EnvidoCalled struct {
+	Player *Player
+	Call   EnvidoCall
+}
+
+// EnvidoAccepted is sent when the envido is accepted, the players announce
+// their points next
+type EnvidoAccepted struct {
+	Player *Player
+}
+
+// EnvidoRefused is sent when the envido is refused, an EnvidoScored event
+// follows
+type EnvidoRefused struct {
+	Player *Player
+}
+
+// EnvidoAnnounced is sent when a player shows their envido points or concedes
+type EnvidoAnnounced struct {
+	Player *Player
+	// 0 if the player conceded
+	Points   int
+	Conceded bool
+}
+
+// EnvidoScored is sent when the envido is settled
+type EnvidoScored struct {
+	Team   int
+	Points int
+}
+
+// FlorCalled is sent when a player sings or raises a flor
+type FlorCalled struct {
+	Player *Player
+	Call   FlorCall
+}
+
+// FlorAccepted is sent when a contra flor is accepted, a FlorScored event
+// follows
+type FlorAccepted struct {
+	Player *Player
+}
+
+// FlorRefused is sent when a player backs down from a flor, a FlorScored event
+// follows
+type FlorRefused struct {
+	Player *Player
+}
+
+// FlorScored is sent when the flor is settled
+type FlorScored struct {
+	Team   int
+	Points int
+}
+
+// MaoDeOnzeAccepted is sent when the side with eleven points plays the hand
+type MaoDeOnzeAccepted struct {
+	Player *Player
+}
+
+// MaoDeOnzeRefused is sent when the side with eleven points gives up the
+// hand, a HandWon event follows
+type MaoDeOnzeRefused struct {
+	Player *Player
+}
+
+// GameOver is sent when a team reaches the target score
+type GameOver struct {
+	Team int
+}
+
+func (HandDealt) event()         {}
+func (DeckCut) event()           {}
+func (CardPlayed) event()        {}
+func (RoundWon) event()          {}
+func (RoundTied) event()         {}
+func (HandWon) event()           {}
+func (TrucoCalled) event()       {}
+func (TrucoAccepted) event()     {}
+func (TrucoRefused) event()      {}
+func (Folded) event()            {}
+func (EnvidoCalled) event()      {}
+func (EnvidoAccepted) event()    {}
+func (EnvidoRefused) event()     {}
+func (EnvidoAnnounced) event()   {}
+func (EnvidoScored) event()      {}
+func (FlorCalled) event()        {}
+func (FlorAccepted) event()      {}
+func (FlorRefused) event()       {}
+func (FlorScored) event()        {}
+func (MaoDeOnzeAccepted) event() {}
+func (MaoDeOnzeRefused) event()  {}
+func (GameOver) event()          {}
+
+// Subscribe registers a function called with every event of the game as soon
+// as it happens
+func (g *Game) Subscribe(fn func(event Event)) {
+	g.subscribers = append(g.subscribers, fn)
+}
+
+// emit sends the event to the subscribers and keeps it to be returned by the
+// action that caused it
+func (g *Game) emit(event Event) {
+	g.events = append(g.events, event)
+	for _, fn := range g.subscribers {
+		fn(event)
+	}
+}
+
+// flush returns the events of the action along with its error
+func (g *Game) flush(err error) ([]Event, error) {
+	events := g.events
+	g.events = nil
+	return events, err
+}
@@ -0,0 +1,211 @@
+package truco
+
+import "testing"
+
+func TestEvents(t *testing.T) {
+	g, err := NewGame(WithSeed(123, 456))
+	if err != nil {
+		t.Fatal("failed to create game: " + err.Error())
+	}
+	addPlayers(t, g)
+	received := make([]Event, 0)
+	g.Subscribe(func(event Event) {
+		received = append(received, event)
+	})
+	events, err := g.Start()
+	if err != nil {
+		t.Fatal("failed to start game: " + err.Error())
+	}
+	p1 := g.players[0]
+	p2 := g.players[1]
+
+	if len(events) != 1 || events[0] != (HandDealt{Hand: 0, Dealer: p2, Manilha: ThreeHearts}) {
+		t.Errorf("expected the first hand to be dealt, instead got: %v", events)
+	}
+	events, err = g.Truco(p1)
+	if err != nil {
+		t.Fatal("failed to call truco: " + err.Error())
+	}
+	if len(events) != 1 || events[0] != (TrucoCalled{Player: p1, Value: 3}) {
+		t.Errorf("expected truco to be called, instead got: %v", events)
+	}
+	if events, err := g.Truco(p1); err != ErrOwnCall || events != nil {
+		t.Errorf("expected error ErrOwnCall and no events, instead got: %v %v", err, events)
+	}
+	events, err = g.Accept(p2)
+	if err != nil {
+		t.Fatal("failed to accept truco: " + err.Error())
+	}
+	checkEvents(t, events, []Event{TrucoAccepted{Player: p2, Value: 3}})
+
+	if _, err := g.Play(p1, QueenSpades); err != nil {
+		t.Fatal("failed to play card: " + err.Error())
+	}
+	events, err = g.Play(p2, SevenClubs)
+	if err != nil {
+		t.Fatal("failed to play card: " + err.Error())
+	}
+	expected := []Event{
+		CardPlayed{Player: p2, Card: SevenClubs},
+		RoundWon{Round: 0, Player: p1, Team: 0},
+	}
+	checkEvents(t, events, expected)
+
+	if _, err := g.Play(p1, QueenHearts); err != nil {
+		t.Fatal("failed to play card: " + err.Error())
+	}
+	if _, err := g.Play(p2, AceSpades); err != nil {
+		t.Fatal("failed to play card: " + err.Error())
+	}
+	events, err = g.PlayFaceDown(p2, ThreeClubs)
+	if err != nil {
+		t.Fatal("failed to play card: " + err.Error())
+	}
+	checkEvents(t, events, []Event{CardPlayed{Player: p2, Card: CardBack, FaceDown: true}})
+	events, err = g.Play(p1, ThreeDiamonds)
+	if err != nil {
+		t.Fatal("failed to play card: " + err.Error())
+	}
+	expected = []Event{
+		CardPlayed{Player: p1, Card: ThreeDiamonds},
+		RoundWon{Round: 2, Player: p1, Team: 0},
+		HandWon{Team: 0, Points: 3},
+		HandDealt{Hand: 1, Dealer: p1, Manilha: g.Manilha()},
+	}
+	checkEvents(t, events, expected)
+
+	g.score[1] = 11
+	events, err = g.Fold(p2)
+	if err != nil {
+		t.Fatal("failed to fold: " + err.Error())
+	}
+	checkEvents(t, events, []Event{Folded{Player: p2}, HandWon{Team: 0, Points: 1}, HandDealt{Hand: 2, Dealer: p2, Manilha: g.Manilha()}})
+	g.score[0] = 11
+	events, err = g.RefuseMaoDeOnze(p2)
+	if err != nil {
+		t.Fatal("failed to refuse mão de onze: " + err.Error())
+	}
+	checkEvents(t, events, []Event{MaoDeOnzeRefused{Player: p2}, HandWon{Team: 0, Points: 1}, GameOver{Team: 0}})
+	if len(received) != 20 {
+		t.Errorf("expected subscriber to receive 20 events, instead got %d: %v", len(received), received)
+	}
+}
+
+func TestEnvidoEvents(t *testing.T) {
+	g := argentinoGame(t)
+	p1 := g.players[0]
+	p2 := g.players[1]
+
+	events, err := g.CallEnvido(p1, Envido)
+	if err != nil {
+		t.Fatal("failed to call envido: " + err.Error())
+	}
+	checkEvents(t, events, []Event{EnvidoCalled{Player: p1, Call: Envido}})
+	if events, err := g.CallEnvido(p1, RealEnvido); err != ErrOwnCall || events != nil {
+		t.Errorf("expected error ErrOwnCall and no events, instead got: %v %v", err, events)
+	}
+	if _, err := g.CallEnvido(p2, RealEnvido); err != nil {
+		t.Fatal("failed to raise to real envido: " + err.Error())
+	}
+	events, err = g.Accept(p1)
+	if err != nil {
+		t.Fatal("failed to accept real envido: " + err.Error())
+	}
+	checkEvents(t, events, []Event{EnvidoAccepted{Player: p1}})
+	events, err = g.AnnounceEnvido(p1)
+	if err != nil {
+		t.Fatal("failed to announce envido: " + err.Error())
+	}
+	checkEvents(t, events, []Event{EnvidoAnnounced{Player: p1, Points: 23}})
+	events, err = g.ConcedeEnvido(p2)
+	if err != nil {
+		t.Fatal("failed to concede envido: " + err.Error())
+	}
+	checkEvents(t, events, []Event{EnvidoAnnounced{Player: p2, Conceded: true}, EnvidoScored{Team: 0, Points: 5}})
+
+	g = argentinoGame(t)
+	if _, err := g.CallEnvido(g.players[0], Envido); err != nil {
+		t.Fatal("failed to call envido: " + err.Error())
+	}
+	events, err = g.Refuse(g.players[1])
+	if err != nil {
+		t.Fatal("failed to refuse envido: " + err.Error())
+	}
+	checkEvents(t, events, []Event{EnvidoRefused{Player: g.players[1]}, EnvidoScored{Team: 0, Points: 1}})
+}
+
+func TestFlorEvents(t *testing.T) {
+	g := florGame(t)
+	p1 := g.players[0]
+	p2 := g.players[1]
+	setCards(p1, ThreeHearts, QueenHearts, TwoHearts)
+	setCards(p2, AceSpades, SevenSpades, SixSpades)
+
+	events, err := g.CallFlor(p1, Flor)
+	if err != nil {
+		t.Fatal("failed to call flor: " + err.Error())
+	}
+	checkEvents(t, events, []Event{FlorCalled{Player: p1, Call: Flor}})
+	events, err = g.CallFlor(p2, ContraFlor)
+	if err != nil {
+		t.Fatal("failed to call contra flor: " + err.Error())
+	}
+	checkEvents(t, events, []Event{FlorCalled{Player: p2, Call: ContraFlor}})
+	events, err = g.Accept(p1)
+	if err != nil {
+		t.Fatal("failed to accept contra flor: " + err.Error())
+	}
+	checkEvents(t, events, []Event{FlorAccepted{Player: p1}, FlorScored{Team: 1, Points: 6}})
+
+	g = florGame(t)
+	p1 = g.players[0]
+	p2 = g.players[1]
+	setCards(p1, ThreeHearts, QueenHearts, TwoHearts)
+	setCards(p2, AceSpades, SevenSpades, SixSpades)
+	if _, err := g.CallFlor(p1, Flor); err != nil {
+		t.Fatal("failed to call flor: " + err.Error())
+	}
+	events, err = g.Refuse(p2)
+	if err != nil {
+		t.Fatal("failed to refuse flor: " + err.Error())
+	}
+	checkEvents(t, events, []Event{FlorRefused{Player: p2}, FlorScored{Team: 0, Points: 4}})
+
+	// nobody else has flor, the points are scored right away
+	g = florGame(t)
+	p1 = g.players[0]
+	setCards(p1, ThreeHearts, QueenHearts, TwoHearts)
+	setCards(g.players[1], AceSpades, SevenClubs, SixSpades)
+	events, err = g.CallFlor(p1, Flor)
+	if err != nil {
+		t.Fatal("failed to call flor: " + err.Error())
+	}
+	checkEvents(t, events, []Event{FlorCalled{Player: p1, Call: Flor}, FlorScored{Team: 0, Points: 3}})
+}
+
+func TestMaoDeOnzeEvents(t *testing.T) {
+	g := maoDeOnzeGame(t)
+	p1 := g.players[0]
+
+	if events, err := g.AcceptMaoDeOnze(g.players[1]); err != ErrNotMaoDeOnzeSide || events != nil {
+		t.Errorf("expected error ErrNotMaoDeOnzeSide and no events, instead got: %v %v", err, events)
+	}
+	events, err := g.AcceptMaoDeOnze(p1)
+	if err != nil {
+		t.Fatal("failed to accept mão de onze: " + err.Error())
+	}
+	checkEvents(t, events, []Event{MaoDeOnzeAccepted{Player: p1}})
+}
+
+func checkEvents(t *testing.T, events, expected []Event) {
+	t.Helper()
+	if len(events) != len(expected) {
+		t.Errorf("expected events %v, instead got: %v", expected, events)
+		return
+	}
+	for i := range expected {
+		if events[i] != expected[i] {
+			t.Errorf("expected event %v, instead got: %v", expected[i], events[i])
+		}
+	}
+}
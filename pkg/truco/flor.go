@@ -0,0 +1,233 @@
+package truco
+
+// FlorCall is a bet made with a flor, three cards of the same suit. Calling
+// flor cancels the envido of the hand
+type FlorCall int
+
+const (
+	// Flor is worth 3 points if the other team has no flor. If they have one,
+	// they can back down ("con flor me achico") giving 4 points or raise
+	Flor FlorCall = iota
+	// ContraFlor is worth 6 points to the best flor, refusing it gives 4
+	// points to the team that called it
+	ContraFlor
+	// ContraFlorAlResto is worth the points the leading team needs to win the
+	// game, refusing it gives the team that called it the previous call value
+	ContraFlorAlResto
+)
+
+// florCallNames are the names of the flor calls in saved games
+var florCallNames = map[FlorCall]string{
+	Flor:              "flor",
+	ContraFlor:        "contraFlor",
+	ContraFlorAlResto: "contraFlorAlResto",
+}
+
+func (c FlorCall) MarshalText() ([]byte, error) {
+	return marshalName(florCallNames, c, ErrUnknownName)
+}
+
+func (c *FlorCall) UnmarshalText(text []byte) error {
+	return unmarshalName(florCallNames, text, c, ErrUnknownName)
+}
+
+// flor is the state of the flor bet in a hand
+type flor struct {
+	// calls made so far, in order
+	calls []FlorCall
+	// position of the player who made the last call
+	position int
+	// the last call is waiting for an answer
+	pending bool
+	// the bet was settled and can't be called again in the hand
+	done bool
+}
+
+// SetFlor enables flor for rules played with envido
+func (g *Game) SetFlor(enabled bool) error {
+	if g.running {
+		return ErrGameRunning
+	}
+	if enabled && !g.rules.Envido {
+		return ErrFlorNotAllowed
+	}
+	g.rules.Flor = enabled
+	return nil
+}
+
+// CallFlor sings the player's flor on their turn before they play their first
+// card, or instead of answering an envido or truco call. A player of the other
+// team who also has a flor raises it with ContraFlor or ContraFlorAlResto
+func (g *Game) CallFlor(player *Player, bet FlorCall) ([]Event, error) {
+	move := Move{Action: ActionCallFlor, Player: g.position(player), Flor: bet}
+	if !g.running {
+		return nil, ErrGameNotRunning
+	}
+	position := g.position(player)
+	if position == -1 {
+		return nil, ErrPlayerNotFound
+	}
+	if !g.hand().inHand(position) {
+		return nil, ErrNotInHand
+	}
+	if !g.rules.Flor || !g.rules.Envido {
+		return nil, ErrFlorNotAllowed
+	}
+	h := g.hand()
+	if h.phase == phaseCut {
+		return nil, ErrCutPending
+	}
+	if !g.hasFlor(position) {
+		return nil, ErrNoFlor
+	}
+
+	if h.flor != nil {
+		if !h.flor.pending {
+			return nil, ErrFlorClosed
+		}
+		if g.team(h.flor.position) == g.team(position) {
+			return nil, ErrOwnCall
+		}
+		if !validFlorCall(h.flor.calls, bet) {
+			return nil, ErrInvalidFlorCall
+		}
+		h.flor.calls = append(h.flor.calls, bet)
+		h.flor.position = position
+		g.emit(FlorCalled{Player: player, Call: bet})
+		return g.record(move, nil)
+	}
+
+	if bet != Flor {
+		return nil, ErrInvalidFlorCall
+	}
+	if h.envidoOpen() && h.envido.pending && g.team(h.envido.position) != g.team(position) {
+		// the flor answers the envido call, which is dropped
+		if h.round != 0 {
+			return nil, ErrFlorClosed
+		}
+		for _, c := range h.pile {
+			if c.position == position {
+				return nil, ErrFlorClosed
+			}
+		}
+	} else if h.envidoOpen() {
+		return nil, ErrEnvidoPending
+	} else if h.envido != nil {
+		return nil, ErrFlorClosed
+	} else if err := g.checkEnvidoOpen(position); err != nil {
+		if err == ErrEnvidoClosed {
+			return nil, ErrFlorClosed
+		}
+		return nil, err
+	}
+
+	if h.envido == nil {
+		h.envido = &envido{best: -1}
+	}
+	h.envido.pending = false
+	h.envido.done = true
+	h.flor = &flor{calls: []FlorCall{Flor}, position: position, pending: true}
+	g.emit(FlorCalled{Player: player, Call: Flor})
+	if !g.teamHasFlor(g.team(position) ^ 1) {
+		h.flor.pending = false
+		h.flor.done = true
+		return g.record(move, g.scoreFlor(g.team(position), 3))
+	}
+	return g.record(move, nil)
+}
+
+// validFlorCall returns true if the raise can follow the previous calls
+func validFlorCall(calls []FlorCall, bet FlorCall) bool {
+	return bet > calls[len(calls)-1]
+}
+
+// acceptFlor compares the flores of both teams, the best one wins the called
+// value and ties go to the player closer to the hand leader
+func (g *Game) acceptFlor(player *Player) error {
+	f := g.hand().flor
+	if g.team(f.position) == g.team(g.position(player)) {
+		return ErrOwnCall
+	}
+	if f.calls[len(f.calls)-1] == Flor {
+		// backing down or raising are the only answers to a flor
+		return ErrInvalidFlorCall
+	}
+	best, bestPoints := -1, -1
+	for _, seat := range g.hand().seats {
+		if points := g.florPoints(seat); points > bestPoints {
+			best, bestPoints = seat, points
+		}
+	}
+	f.pending = false
+	f.done = true
+	g.emit(FlorAccepted{Player: player})
+	return g.scoreFlor(g.team(best), g.florValue(f.calls[len(f.calls)-1]))
+}
+
+// refuseFlor backs down from the last call, the team that made it scores 4
+// points, or the value of the contra flor if it was raised to al resto
+func (g *Game) refuseFlor(player *Player) error {
+	f := g.hand().flor
+	if g.team(f.position) == g.team(g.position(player)) {
+		return ErrOwnCall
+	}
+	value := 4
+	if len(f.calls) > 2 {
+		value = g.florValue(f.calls[len(f.calls)-2])
+	}
+	f.pending = false
+	f.done = true
+	g.emit(FlorRefused{Player: player})
+	return g.scoreFlor(g.team(f.position), value)
+}
+
+func (g *Game) scoreFlor(team, points int) error {
+	g.emit(FlorScored{Team: team, Points: points})
+	return g.addPoints(team, points)
+}
+
+func (g *Game) florValue(bet FlorCall) int {
+	switch bet {
+	case ContraFlor:
+		return 6
+	case ContraFlorAlResto:
+		return g.faltaEnvido()
+	default:
+		return 3
+	}
+}
+
+// florOpen returns true if the flor bet is waiting for an answer
+func (h *Hand) florOpen() bool {
+	return h.flor != nil && h.flor.pending
+}
+
+func (g *Game) teamHasFlor(team int) bool {
+	for _, seat := range g.hand().seats {
+		if g.team(seat) == team && g.hasFlor(seat) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasFlor returns true if the three cards dealt to the player have the same suit
+func (p *Player) HasFlor() bool {
+	if len(p.dealt) != 3 {
+		return false
+	}
+	return p.dealt[0][0] == p.dealt[1][0] && p.dealt[0][0] == p.dealt[2][0]
+}
+
+// FlorPoints returns 20 plus the envido value of the three cards if the player
+// has a flor, 0 otherwise
+func (p *Player) FlorPoints() int {
+	if !p.HasFlor() {
+		return 0
+	}
+	points := 20
+	for _, c := range p.dealt {
+		points += envidoValue(c)
+	}
+	return points
+}
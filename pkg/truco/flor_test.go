@@ -0,0 +1,143 @@
+package truco
+
+import "testing"
+
+func TestHasFlor(t *testing.T) {
+	p, err := NewPlayer("player 1")
+	if err != nil {
+		t.Fatal("failed to create player: " + err.Error())
+	}
+	setCards(p, SevenSpades, KingSpades, AceSpades)
+	if !p.HasFlor() || p.FlorPoints() != 28 {
+		t.Errorf("expected a flor of 28, instead got: %t %d", p.HasFlor(), p.FlorPoints())
+	}
+	setCards(p, SevenSpades, KingSpades, AceHearts)
+	if p.HasFlor() || p.FlorPoints() != 0 {
+		t.Error("player should not have a flor")
+	}
+}
+
+func TestFlorAgainstEnvido(t *testing.T) {
+	g := florGame(t)
+	p1 := g.players[0]
+	p2 := g.players[1]
+	setCards(p2, AceSpades, SevenSpades, SixSpades)
+
+	if _, err := g.CallFlor(p1, Flor); err != ErrNoFlor {
+		t.Errorf("expected error ErrNoFlor, instead got: %v", err)
+	}
+	if _, err := g.CallEnvido(p1, Envido); err != nil {
+		t.Error("failed to call envido: " + err.Error())
+	}
+	// the flor answers the envido, player 1 has no flor so it is worth 3
+	if _, err := g.CallFlor(p2, Flor); err != nil {
+		t.Error("failed to call flor: " + err.Error())
+	}
+	if g.Scores()[0] != 0 || g.Scores()[1] != 3 {
+		t.Errorf("expected score to be [0 3], instead got: %v", g.Scores())
+	}
+	if _, err := g.CallEnvido(p1, Envido); err != ErrEnvidoClosed {
+		t.Errorf("expected error ErrEnvidoClosed, instead got: %v", err)
+	}
+	if _, err := g.Accept(p1); err != ErrNoCallPending {
+		t.Errorf("expected error ErrNoCallPending, instead got: %v", err)
+	}
+	if _, err := g.Play(p1, QueenSpades); err != nil {
+		t.Error("failed to play card: " + err.Error())
+	}
+}
+
+func TestContraFlor(t *testing.T) {
+	g := florGame(t)
+	p1 := g.players[0]
+	p2 := g.players[1]
+	setCards(p1, ThreeHearts, QueenHearts, TwoHearts)
+	setCards(p2, AceSpades, SevenSpades, SixSpades)
+
+	if _, err := g.CallFlor(p1, ContraFlor); err != ErrInvalidFlorCall {
+		t.Errorf("expected error ErrInvalidFlorCall, instead got: %v", err)
+	}
+	if _, err := g.CallFlor(p1, Flor); err != nil {
+		t.Error("failed to call flor: " + err.Error())
+	}
+	if _, err := g.Play(p1, ThreeHearts); err != ErrFlorPending {
+		t.Errorf("expected error ErrFlorPending, instead got: %v", err)
+	}
+	if _, err := g.Accept(p2); err != ErrInvalidFlorCall {
+		t.Errorf("expected error ErrInvalidFlorCall, instead got: %v", err)
+	}
+	if _, err := g.CallFlor(p2, ContraFlor); err != nil {
+		t.Error("failed to call contra flor: " + err.Error())
+	}
+	if _, err := g.Accept(p1); err != nil {
+		t.Error("failed to accept contra flor: " + err.Error())
+	}
+	// 34 beats 25
+	if g.Scores()[0] != 0 || g.Scores()[1] != 6 {
+		t.Errorf("expected score to be [0 6], instead got: %v", g.Scores())
+	}
+}
+
+func TestRefuseFlor(t *testing.T) {
+	g := florGame(t)
+	p1 := g.players[0]
+	p2 := g.players[1]
+	setCards(p1, ThreeHearts, QueenHearts, TwoHearts)
+	setCards(p2, AceSpades, SevenSpades, SixSpades)
+
+	if _, err := g.CallFlor(p1, Flor); err != nil {
+		t.Error("failed to call flor: " + err.Error())
+	}
+	// con flor me achico
+	if _, err := g.Refuse(p2); err != nil {
+		t.Error("failed to refuse flor: " + err.Error())
+	}
+	if g.Scores()[0] != 4 {
+		t.Errorf("expected player 1 to score 4 points, instead got: %d", g.Scores()[0])
+	}
+}
+
+func TestContraFlorAlResto(t *testing.T) {
+	g := florGame(t)
+	p1 := g.players[0]
+	p2 := g.players[1]
+	setCards(p1, ThreeHearts, QueenHearts, TwoHearts)
+	setCards(p2, AceSpades, SevenSpades, SixSpades)
+	g.score[1] = 20
+
+	if _, err := g.CallFlor(p1, Flor); err != nil {
+		t.Error("failed to call flor: " + err.Error())
+	}
+	if _, err := g.CallFlor(p2, ContraFlor); err != nil {
+		t.Error("failed to call contra flor: " + err.Error())
+	}
+	if _, err := g.CallFlor(p1, ContraFlorAlResto); err != nil {
+		t.Error("failed to call contra flor al resto: " + err.Error())
+	}
+	if _, err := g.Refuse(p2); err != nil {
+		t.Error("failed to refuse contra flor al resto: " + err.Error())
+	}
+	// the value of the contra flor goes to player 1
+	if g.Scores()[0] != 6 {
+		t.Errorf("expected player 1 to score 6 points, instead got: %d", g.Scores()[0])
+	}
+}
+
+func TestFlorNotAllowed(t *testing.T) {
+	g := argentinoGame(t)
+	setCards(g.players[0], ThreeHearts, QueenHearts, TwoHearts)
+	if _, err := g.CallFlor(g.players[0], Flor); err != ErrFlorNotAllowed {
+		t.Errorf("expected error ErrFlorNotAllowed, instead got: %v", err)
+	}
+}
+
+// florGame returns a started Truco Argentino game with flor
+func florGame(t *testing.T) *Game {
+	return startedGame(t, WithVariant(Argentino), WithFlor(true))
+}
+
+// setCards replaces the cards dealt to the player
+func setCards(p *Player, cards ...Card) {
+	p.cards = append([]Card(nil), cards...)
+	p.dealt = append([]Card(nil), cards...)
+}
@@ -0,0 +1,120 @@
+package truco
+
+import (
+	"maps"
+	"slices"
+)
+
+// LegalActions returns every move the player can make right now, each of them
+// can be passed to Apply. The moves are found by trying them on a copy of the
+// game, so they follow the same rules as the actions
+func (g *Game) LegalActions(player *Player) []Move {
+	position := g.position(player)
+	if position == -1 || !g.running {
+		return nil
+	}
+	legal := make([]Move, 0)
+	for _, m := range g.candidateMoves(position) {
+		if m.Action == ActionCut {
+			// every position is valid if one is, only the choices can fail
+			if _, err := g.clone().Apply(m); err != nil {
+				continue
+			}
+			for i := 1; i < len(g.hand().deck); i++ {
+				m.Position = i
+				legal = append(legal, m)
+			}
+			continue
+		}
+		if _, err := g.clone().Apply(m); err == nil {
+			legal = append(legal, m)
+		}
+	}
+	return legal
+}
+
+// candidateMoves returns the moves that could be made by the player in the
+// position, without checking them
+func (g *Game) candidateMoves(position int) []Move {
+	player := g.players[position]
+	moves := make([]Move, 0)
+	if g.hand().maoDeFerro {
+		// the cards are hidden, they can only be played by position
+		for i := range player.cards {
+			moves = append(moves, Move{Action: ActionPlayPosition, Player: position, Position: i})
+		}
+	} else {
+		for _, c := range player.cards {
+			moves = append(moves, Move{Action: ActionPlay, Player: position, Card: c})
+		}
+		for _, c := range player.cards {
+			moves = append(moves, Move{Action: ActionPlayFaceDown, Player: position, Card: c})
+		}
+	}
+	for _, action := range []Action{ActionTruco, ActionAccept, ActionRefuse, ActionFold} {
+		moves = append(moves, Move{Action: action, Player: position})
+	}
+	for _, bet := range []EnvidoCall{Envido, RealEnvido, FaltaEnvido} {
+		moves = append(moves, Move{Action: ActionCallEnvido, Player: position, Envido: bet})
+	}
+	moves = append(moves,
+		Move{Action: ActionAnnounceEnvido, Player: position},
+		Move{Action: ActionConcedeEnvido, Player: position},
+	)
+	for _, bet := range []FlorCall{Flor, ContraFlor, ContraFlorAlResto} {
+		moves = append(moves, Move{Action: ActionCallFlor, Player: position, Flor: bet})
+	}
+	moves = append(moves,
+		Move{Action: ActionAcceptMaoDeOnze, Player: position},
+		Move{Action: ActionRefuseMaoDeOnze, Player: position},
+	)
+	for _, choices := range [][]CutChoice{nil, {CutBater}, {CutFromBottom}, {CutBater, CutFromBottom}} {
+		moves = append(moves, Move{Action: ActionCut, Player: position, Position: 1, Choices: choices})
+	}
+	return moves
+}
+
+// clone returns a copy of the game to try moves on, without the subscribers
+// and the match. Only the current hand is copied, the previous ones are over
+func (g *Game) clone() *Game {
+	c := *g
+	c.onGameOver = nil
+	c.subscribers = nil
+	c.events = nil
+	c.moves = nil
+	c.score = slices.Clone(g.score)
+	c.hands = append(slices.Clip(g.hands[:len(g.hands)-1]), g.hand().clone())
+	c.players = make([]*Player, len(g.players))
+	for i, p := range g.players {
+		c.players[i] = &Player{id: p.id, name: p.name, cards: slices.Clone(p.cards), dealt: slices.Clone(p.dealt)}
+	}
+	return &c
+}
+
+func (h *Hand) clone() *Hand {
+	c := *h
+	c.deck = slices.Clone(h.deck)
+	c.manilhas = slices.Clone(h.manilhas)
+	c.pile = slices.Clone(h.pile)
+	c.deckWeights = maps.Clone(h.deckWeights)
+	c.points = slices.Clone(h.points)
+	c.roundWinners = slices.Clone(h.roundWinners)
+	c.seats = slices.Clone(h.seats)
+	c.piezas = maps.Clone(h.piezas)
+	if h.call != nil {
+		call := *h.call
+		c.call = &call
+	}
+	if h.envido != nil {
+		envido := *h.envido
+		envido.calls = slices.Clone(h.envido.calls)
+		envido.announcements = slices.Clone(h.envido.announcements)
+		c.envido = &envido
+	}
+	if h.flor != nil {
+		flor := *h.flor
+		flor.calls = slices.Clone(h.flor.calls)
+		c.flor = &flor
+	}
+	return &c
+}
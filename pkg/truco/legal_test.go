@@ -0,0 +1,115 @@
+package truco
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLegalActions(t *testing.T) {
+	g, err := NewGame(WithSeed(123, 456))
+	if err != nil {
+		t.Fatal("failed to create game: " + err.Error())
+	}
+	addPlayers(t, g)
+	if len(g.LegalActions(g.players[0])) != 0 {
+		t.Error("there should be no legal actions before the game starts")
+	}
+	if _, err := g.Start(); err != nil {
+		t.Fatal("failed to start game: " + err.Error())
+	}
+	cp := g.CurrentPlayer()
+	other := g.players[g.position(cp)^1]
+
+	expected := make([]Move, 0)
+	for _, c := range cp.cards {
+		expected = append(expected, Move{Action: ActionPlay, Player: g.position(cp), Card: c})
+	}
+	expected = append(expected,
+		Move{Action: ActionTruco, Player: g.position(cp)},
+		Move{Action: ActionFold, Player: g.position(cp)},
+	)
+	if actions := g.LegalActions(cp); !slices.EqualFunc(actions, expected, equalMoves) {
+		t.Errorf("expected legal actions %v, instead got: %v", expected, actions)
+	}
+	if actions := g.LegalActions(other); len(actions) != 0 {
+		t.Errorf("expected no legal actions out of turn, instead got: %v", actions)
+	}
+
+	if _, err := g.Truco(cp); err != nil {
+		t.Fatal("failed to call truco: " + err.Error())
+	}
+	if actions := g.LegalActions(cp); len(actions) != 0 {
+		t.Errorf("expected no legal actions while waiting for an answer, instead got: %v", actions)
+	}
+	expected = []Move{
+		{Action: ActionTruco, Player: g.position(other)},
+		{Action: ActionAccept, Player: g.position(other)},
+		{Action: ActionRefuse, Player: g.position(other)},
+		{Action: ActionFold, Player: g.position(other)},
+	}
+	if actions := g.LegalActions(other); !slices.EqualFunc(actions, expected, equalMoves) {
+		t.Errorf("expected legal actions %v, instead got: %v", expected, actions)
+	}
+	if len(g.moves) != 1 || len(cp.cards) != 3 || g.hand().call == nil {
+		t.Error("listing legal actions should not change the game")
+	}
+}
+
+func TestLegalActionsEnvido(t *testing.T) {
+	g := argentinoGame(t)
+	p1 := g.players[0]
+
+	actions := g.LegalActions(p1)
+	for _, bet := range []EnvidoCall{Envido, RealEnvido, FaltaEnvido} {
+		if !slices.ContainsFunc(actions, func(m Move) bool { return m.Action == ActionCallEnvido && m.Envido == bet }) {
+			t.Errorf("expected envido call %d to be legal, instead got: %v", bet, actions)
+		}
+	}
+	if slices.ContainsFunc(actions, func(m Move) bool { return m.Action == ActionCallFlor }) {
+		t.Error("flor should not be legal without flor rules")
+	}
+}
+
+func TestLegalActionsCut(t *testing.T) {
+	g := cutGame(t)
+	cutter := g.Cutter()
+	actions := g.LegalActions(cutter)
+	// every cut position with and without each choice
+	if len(actions) != (len(g.hand().deck)-1)*4 {
+		t.Errorf("expected %d cut moves, instead got: %d", (len(g.hand().deck)-1)*4, len(actions))
+	}
+	if _, err := g.Apply(actions[len(actions)-1]); err != nil {
+		t.Fatal("failed to cut the deck: " + err.Error())
+	}
+	if len(g.LegalActions(cutter)) == 0 && len(g.LegalActions(g.players[g.position(cutter)^1])) == 0 {
+		t.Error("somebody should be able to play after the cut")
+	}
+}
+
+// TestLegalActionsGame plays whole games with the first legal action, every
+// listed move has to be accepted by Apply
+func TestLegalActionsGame(t *testing.T) {
+	for _, variant := range []Variant{Paulista, Mineiro, Argentino, Uruguaio, Valenciano} {
+		g := startedGame(t, WithVariant(variant))
+		for moves := 0; g.Running(); moves++ {
+			if moves > 2000 {
+				t.Fatalf("variant %d: game should be over after 2000 moves", variant)
+			}
+			var actions []Move
+			for _, p := range g.players {
+				actions = append(actions, g.LegalActions(p)...)
+			}
+			if len(actions) == 0 {
+				t.Fatalf("variant %d: a running game should have legal actions", variant)
+			}
+			if _, err := g.Apply(actions[moves%len(actions)]); err != nil {
+				t.Fatalf("variant %d: failed to apply legal action %v: %s", variant, actions[moves%len(actions)], err)
+			}
+		}
+	}
+}
+
+func equalMoves(a, b Move) bool {
+	return a.Action == b.Action && a.Player == b.Player && a.Card == b.Card && a.Position == b.Position &&
+		a.Envido == b.Envido && a.Flor == b.Flor && slices.Equal(a.Choices, b.Choices)
+}
@@ -0,0 +1,93 @@
+package truco
+
+// checkMaoDeOnze starts the mão de onze decision when only one side is one
+// hand away from winning the game, or a mão de ferro when both sides are. In
+// Truco Mineiro hands are worth 2 points, so it happens at 10 (mão de dez)
+func (g *Game) checkMaoDeOnze() {
+	if !g.rules.MaoDeOnze {
+		return
+	}
+	maoDeOnzeScore := g.rules.TargetScore - g.rules.Stakes[0]
+	team := -1
+	for i, score := range g.score {
+		if score != maoDeOnzeScore {
+			continue
+		}
+		if team != -1 {
+			g.hand().maoDeFerro = true
+			return
+		}
+		team = i
+	}
+	if team == -1 {
+		return
+	}
+	g.hand().maoDeOnze = team
+	g.hand().phase = phaseMaoDeOnze
+}
+
+// AcceptMaoDeOnze plays the mão de onze, the hand is worth the truco value and
+// nobody can call truco
+func (g *Game) AcceptMaoDeOnze(player *Player) ([]Event, error) {
+	if err := g.checkMaoDeOnzeDecision(player); err != nil {
+		return nil, err
+	}
+	g.hand().stake = 1
+	g.hand().phase = phasePlaying
+	g.emit(MaoDeOnzeAccepted{Player: player})
+	move := Move{Action: ActionAcceptMaoDeOnze, Player: g.position(player)}
+	return g.record(move, nil)
+}
+
+// RefuseMaoDeOnze gives up the mão de onze, the other side scores the value of
+// a hand without calls
+func (g *Game) RefuseMaoDeOnze(player *Player) ([]Event, error) {
+	if err := g.checkMaoDeOnzeDecision(player); err != nil {
+		return nil, err
+	}
+	g.hand().phase = phasePlaying
+	g.emit(MaoDeOnzeRefused{Player: player})
+	move := Move{Action: ActionRefuseMaoDeOnze, Player: g.position(player)}
+	return g.record(move, g.endHand(g.hand().maoDeOnze^1))
+}
+
+// MaoDeOnze returns the first player of the team deciding the mão de onze, nil
+// if the current hand isn't one or the decision was already made. Any player
+// of that team can make the decision
+func (g *Game) MaoDeOnze() *Player {
+	if g.hand().phase != phaseMaoDeOnze {
+		return nil
+	}
+	for _, seat := range g.hand().seats {
+		if g.team(seat) == g.hand().maoDeOnze {
+			return g.players[seat]
+		}
+	}
+	return nil
+}
+
+func (g *Game) checkMaoDeOnzeDecision(player *Player) error {
+	if !g.running {
+		return ErrGameNotRunning
+	}
+	position := g.position(player)
+	if position == -1 {
+		return ErrPlayerNotFound
+	}
+	if !g.hand().inHand(position) {
+		return ErrNotInHand
+	}
+	if g.hand().phase != phaseMaoDeOnze {
+		return ErrNoMaoDeOnze
+	}
+	if g.team(position) != g.hand().maoDeOnze {
+		return ErrNotMaoDeOnzeSide
+	}
+	return nil
+}
+
+// MaoDeFerro returns true if the current hand is played blind because both
+// sides have eleven points
+func (g *Game) MaoDeFerro() bool {
+	return g.hand().maoDeFerro
+}
@@ -0,0 +1,105 @@
+package truco
+
+import "testing"
+
+// maoDeOnzeGame returns a started game where player 1 is playing a mão de onze
+func maoDeOnzeGame(t *testing.T) *Game {
+	g := startedGame(t)
+	g.score[0] = 10
+	if _, err := g.Truco(g.players[0]); err != nil {
+		t.Fatal("failed to call truco: " + err.Error())
+	}
+	if _, err := g.Refuse(g.players[1]); err != nil {
+		t.Fatal("failed to refuse truco: " + err.Error())
+	}
+	return g
+}
+
+func TestMaoDeOnze(t *testing.T) {
+	g := maoDeOnzeGame(t)
+	p1 := g.players[0]
+	p2 := g.players[1]
+
+	if g.MaoDeOnze() != p1 {
+		t.Error("player 1 should be deciding the mão de onze")
+	}
+	// player 2 leads the second hand
+	if _, err := g.Play(p2, p2.cards[0]); err != ErrMaoDeOnzePending {
+		t.Errorf("expected error ErrMaoDeOnzePending, instead got: %v", err)
+	}
+	if _, err := g.AcceptMaoDeOnze(p2); err != ErrNotMaoDeOnzeSide {
+		t.Errorf("expected error ErrNotMaoDeOnzeSide, instead got: %v", err)
+	}
+	if _, err := g.AcceptMaoDeOnze(p1); err != nil {
+		t.Error("failed to accept mão de onze: " + err.Error())
+	}
+	if g.HandValue() != 3 {
+		t.Errorf("expected hand value to be 3, instead got: %d", g.HandValue())
+	}
+	if g.MaoDeOnze() != nil {
+		t.Error("mão de onze should not be waiting for a decision after it was made")
+	}
+	if _, err := g.AcceptMaoDeOnze(p1); err != ErrNoMaoDeOnze {
+		t.Errorf("expected error ErrNoMaoDeOnze, instead got: %v", err)
+	}
+	if _, err := g.Truco(p2); err != ErrTrucoInMaoDeOnze {
+		t.Errorf("expected error ErrTrucoInMaoDeOnze, instead got: %v", err)
+	}
+	if _, err := g.Play(p2, p2.cards[0]); err != nil {
+		t.Error("failed to play card: " + err.Error())
+	}
+	if _, err := g.Truco(p1); err != ErrTrucoInMaoDeOnze {
+		t.Errorf("expected error ErrTrucoInMaoDeOnze, instead got: %v", err)
+	}
+}
+
+func TestRefuseMaoDeOnze(t *testing.T) {
+	g := maoDeOnzeGame(t)
+	p1 := g.players[0]
+
+	if _, err := g.RefuseMaoDeOnze(p1); err != nil {
+		t.Error("failed to refuse mão de onze: " + err.Error())
+	}
+	if g.Scores()[0] != 11 || g.Scores()[1] != 1 {
+		t.Errorf("expected score to be [11 1], instead got: %v", g.Scores())
+	}
+	// player 1 is still at eleven, so the next hand is a mão de onze as well
+	if g.MaoDeOnze() != p1 {
+		t.Error("player 1 should be deciding the mão de onze again")
+	}
+}
+
+func TestMaoDeFerro(t *testing.T) {
+	g := startedGame(t, WithStartingScores(11, 11))
+	p1 := g.players[0]
+
+	if !g.MaoDeFerro() {
+		t.Error("hand should be a mão de ferro")
+	}
+	if g.MaoDeOnze() != nil {
+		t.Error("mão de ferro should not wait for a mão de onze decision")
+	}
+	for _, c := range g.CardsFor(p1) {
+		if c != CardBack {
+			t.Error("cards should be hidden, instead got: " + string(c))
+		}
+	}
+	if _, err := g.Truco(p1); err != ErrTrucoInMaoDeFerro {
+		t.Errorf("expected error ErrTrucoInMaoDeFerro, instead got: %v", err)
+	}
+	if _, err := g.Play(p1, QueenSpades); err != ErrHiddenCards {
+		t.Errorf("expected error ErrHiddenCards, instead got: %v", err)
+	}
+	if _, err := g.PlayPosition(p1, 3); err != ErrInvalidCardPosition {
+		t.Errorf("expected error ErrInvalidCardPosition, instead got: %v", err)
+	}
+	if _, err := g.PlayPosition(p1, 0); err != nil {
+		t.Error("failed to play card by position: " + err.Error())
+	}
+	if g.hand().pile[0].card != QueenSpades {
+		t.Error("wrong card in pile, expected AD, instead got: " + string(g.hand().pile[0].card))
+	}
+	if len(g.CardsFor(p1)) != 2 {
+		t.Error("player 1 should have 2 cards left")
+	}
+}
@@ -0,0 +1,225 @@
+package truco
+
+import (
+	"errors"
+	"slices"
+
+	gonanoid "github.com/matoous/go-nanoid/v2"
+)
+
+var (
+	ErrInvalidMatchLength = errors.New("a match must have an odd number of games")
+	ErrMatchRunning       = errors.New("match has already started")
+	ErrMatchOver          = errors.New("match is over")
+)
+
+// Match is a series of games ("quedas") played by the same players, the team
+// that wins most of the games wins the match
+type Match struct {
+	// ID of the match
+	id string
+	// registered players, they keep their positions in every game
+	players []*Player
+	// max number of players per game
+	maxPlayers int
+	// first seed for the random number generator
+	seed1 uint64
+	// second seed for the random number generator
+	seed2 uint64
+	// options every game is created with
+	opts []Option
+	// games played so far, the last one is the current game
+	games []*Game
+	// games needed to win the match
+	gamesToWin int
+	// games won by each team
+	wins []int
+	// called when each game is over
+	gameOverFuncs []func(game *Game, team int)
+	// called with every event of every game
+	subscribers []func(game *Game, event Event)
+}
+
+// NewMatch creates a match that is won by the first team to win most of the
+// games, 3 being a best of 3. Every game is created with the options
+func NewMatch(games int, opts ...Option) (*Match, error) {
+	if games < 1 || games%2 == 0 {
+		return nil, ErrInvalidMatchLength
+	}
+	// check the options before the first game
+	g, err := NewGame(opts...)
+	if err != nil {
+		return nil, err
+	}
+	id, err := gonanoid.New()
+	if err != nil {
+		return nil, err
+	}
+	match := Match{
+		id:         id,
+		players:    make([]*Player, 0),
+		maxPlayers: g.maxPlayers,
+		opts:       slices.Clone(opts),
+		games:      make([]*Game, 0),
+		gamesToWin: games/2 + 1,
+		wins:       make([]int, 2),
+	}
+	return &match, nil
+}
+
+// Seed seeds the games of the match so the same cards are dealt every time
+func (m *Match) Seed(seed1, seed2 uint64) error {
+	if m.running() {
+		return ErrMatchRunning
+	}
+	m.seed1 = seed1
+	m.seed2 = seed2
+	return nil
+}
+
+// SetMaxPlayers changes how many players each game has, see Game.SetMaxPlayers
+func (m *Match) SetMaxPlayers(maxPlayers int) error {
+	if m.running() {
+		return ErrMatchRunning
+	}
+	if maxPlayers != 2 && maxPlayers != 4 && maxPlayers != 6 {
+		return ErrInvalidPlayerCount
+	}
+	if len(m.players) > maxPlayers {
+		return ErrGameFull
+	}
+	m.maxPlayers = maxPlayers
+	return nil
+}
+
+// SetTieRule chooses how tied rounds decide the hands of every game
+func (m *Match) SetTieRule(rule TieRule) error {
+	if m.running() {
+		return ErrMatchRunning
+	}
+	m.opts = append(m.opts, WithTieRule(rule))
+	return nil
+}
+
+func (m *Match) AddPlayer(player *Player) error {
+	if m.running() {
+		return ErrMatchRunning
+	}
+	if m.maxPlayers == len(m.players) {
+		return ErrGameFull
+	}
+	for _, p := range m.players {
+		if p.id == player.id {
+			return ErrPlayerAlreadyInGame
+		}
+	}
+	m.players = append(m.players, player)
+	return nil
+}
+
+// OnGameOver registers a function called with the game and the winner team
+// every time a game of the match is over
+func (m *Match) OnGameOver(fn func(game *Game, team int)) {
+	m.gameOverFuncs = append(m.gameOverFuncs, fn)
+}
+
+// Subscribe registers a function called with the game and every event of the
+// games of the match
+func (m *Match) Subscribe(fn func(game *Game, event Event)) {
+	m.subscribers = append(m.subscribers, fn)
+}
+
+// Start starts the first game of the match
+func (m *Match) Start() error {
+	if m.Finished() {
+		return ErrMatchOver
+	}
+	if m.running() {
+		return ErrMatchRunning
+	}
+	if len(m.players) != m.maxPlayers {
+		return ErrNotEnoughPlayers
+	}
+	return m.nextGame()
+}
+
+// nextGame starts a new game with the same seats, the deal keeps rotating from
+// where the previous game stopped
+func (m *Match) nextGame() error {
+	g, err := NewGame(append(m.opts, WithMaxPlayers(m.maxPlayers))...)
+	if err != nil {
+		return err
+	}
+	for _, p := range m.players {
+		if err := g.AddPlayer(p); err != nil {
+			return err
+		}
+	}
+	if m.seed2 != 0 {
+		if err := g.Seed(m.seed1, m.seed2+uint64(len(m.games))); err != nil {
+			return err
+		}
+	}
+	if len(m.games) != 0 {
+		previous := m.Game()
+		g.dealer = (previous.hand().dealer + 1) % len(m.players)
+	}
+	for _, fn := range m.subscribers {
+		g.Subscribe(func(event Event) {
+			fn(g, event)
+		})
+	}
+	g.onGameOver = m.gameOver
+	m.games = append(m.games, g)
+	_, err = g.Start()
+	return err
+}
+
+func (m *Match) gameOver(team int) error {
+	m.wins[team] += 1
+	for _, fn := range m.gameOverFuncs {
+		fn(m.Game(), team)
+	}
+	if m.Finished() {
+		return nil
+	}
+	return m.nextGame()
+}
+
+// Game returns the game being played, or the last game if the match is over
+func (m *Match) Game() *Game {
+	if len(m.games) == 0 {
+		return nil
+	}
+	return m.games[len(m.games)-1]
+}
+
+// Games returns how many games were started
+func (m *Match) Games() int {
+	return len(m.games)
+}
+
+// Wins returns how many games each team won
+func (m *Match) Wins() []int {
+	wins := make([]int, len(m.wins))
+	copy(wins, m.wins)
+	return wins
+}
+
+// Winner returns the team that won the match, -1 if it isn't over
+func (m *Match) Winner() int {
+	for team, w := range m.wins {
+		if w >= m.gamesToWin {
+			return team
+		}
+	}
+	return -1
+}
+
+func (m *Match) Finished() bool {
+	return m.Winner() != -1
+}
+
+func (m *Match) running() bool {
+	return len(m.games) != 0 && !m.Finished()
+}
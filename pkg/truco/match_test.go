@@ -0,0 +1,113 @@
+package truco
+
+import "testing"
+
+func TestNewMatch(t *testing.T) {
+	if _, err := NewMatch(2); err != ErrInvalidMatchLength {
+		t.Errorf("expected error ErrInvalidMatchLength, instead got: %v", err)
+	}
+	m, err := NewMatch(3)
+	if err != nil {
+		t.Fatal("failed to create match: " + err.Error())
+	}
+	if m.gamesToWin != 2 {
+		t.Errorf("expected 2 games to win the match, instead got: %d", m.gamesToWin)
+	}
+	if err := m.Start(); err != ErrNotEnoughPlayers {
+		t.Errorf("expected error ErrNotEnoughPlayers, instead got: %v", err)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	m, err := NewMatch(3)
+	if err != nil {
+		t.Fatal("failed to create match: " + err.Error())
+	}
+	if err := m.Seed(123, 456); err != nil {
+		t.Fatal("failed to seed match: " + err.Error())
+	}
+	for _, name := range []string{"player 1", "player 2"} {
+		p, err := NewPlayer(name)
+		if err != nil {
+			t.Fatal("failed to create player: " + err.Error())
+		}
+		if err := m.AddPlayer(p); err != nil {
+			t.Fatal("failed to add player: " + err.Error())
+		}
+	}
+	finished := make([]int, 0)
+	m.OnGameOver(func(game *Game, team int) {
+		if game.Running() {
+			t.Error("game should be over")
+		}
+		finished = append(finished, team)
+	})
+	dealt := make(map[*Game]int)
+	m.Subscribe(func(game *Game, event Event) {
+		if _, ok := event.(HandDealt); ok {
+			dealt[game] += 1
+		}
+	})
+	if err := m.Start(); err != nil {
+		t.Fatal("failed to start match: " + err.Error())
+	}
+	if err := m.AddPlayer(m.players[0]); err != ErrMatchRunning {
+		t.Errorf("expected error ErrMatchRunning, instead got: %v", err)
+	}
+	if err := m.Seed(1, 2); err != ErrMatchRunning {
+		t.Errorf("expected error ErrMatchRunning, instead got: %v", err)
+	}
+
+	first := m.Game()
+	winGame(t, first, 1)
+	if m.Games() != 2 || m.Game() == first {
+		t.Fatal("second game should have started")
+	}
+	if m.Game().players[0] != first.players[0] {
+		t.Error("players should keep their seats between games")
+	}
+	if m.Game().Dealer() != first.players[(first.hand().dealer+1)%2] {
+		t.Error("the deal should keep rotating between games")
+	}
+	winGame(t, m.Game(), 0)
+	if m.Winner() != -1 {
+		t.Error("match should not be over after 1 game each")
+	}
+	winGame(t, m.Game(), 1)
+	if m.Winner() != 1 || !m.Finished() {
+		t.Error("team 1 should have won the match")
+	}
+	if m.Games() != 3 {
+		t.Errorf("expected 3 games, instead got: %d", m.Games())
+	}
+	if len(finished) != 3 || finished[0] != 1 || finished[1] != 0 || finished[2] != 1 {
+		t.Errorf("expected game over events for teams [1 0 1], instead got: %v", finished)
+	}
+	if len(dealt) != 3 {
+		t.Errorf("expected events from 3 games, instead got: %d", len(dealt))
+	}
+	if m.Wins()[0] != 1 || m.Wins()[1] != 2 {
+		t.Errorf("expected wins to be [1 2], instead got: %v", m.Wins())
+	}
+	if err := m.Start(); err != ErrMatchOver {
+		t.Errorf("expected error ErrMatchOver, instead got: %v", err)
+	}
+}
+
+// winGame makes the team win the game by scoring the last point
+func winGame(t *testing.T, g *Game, team int) {
+	g.score[team] = g.rules.TargetScore - 1
+	cp := g.CurrentPlayer()
+	if g.Team(cp) != team {
+		if _, err := g.Fold(cp); err != nil {
+			t.Fatal("failed to fold: " + err.Error())
+		}
+		return
+	}
+	if _, err := g.Truco(cp); err != nil {
+		t.Fatal("failed to call truco: " + err.Error())
+	}
+	if _, err := g.Refuse(g.TeamPlayers(team ^ 1)[0]); err != nil {
+		t.Fatal("failed to refuse truco: " + err.Error())
+	}
+}
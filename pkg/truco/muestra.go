@@ -0,0 +1,113 @@
+package truco
+
+// piezaRanks are the ranks of the muestra suit that beat every other card, from
+// the lowest to the highest, with their envido value
+var piezaRanks = []struct {
+	rank   string
+	envido int
+}{
+	{Jack, 27},
+	{Queen, 27},
+	{Five, 28},
+	{Four, 29},
+	{Two, 30},
+}
+
+// setMuestra turns up the muestra and makes the piezas of its suit the highest
+// cards. If the muestra is a pieza, the king of the suit takes its place
+// (alcahuete)
+func (h *Hand) setMuestra() {
+	h.manilha = h.deck[0]
+	h.deckPosition += 1
+
+	suit := string(h.manilha[0])
+	h.piezas = make(map[Card]int)
+	for i, pieza := range piezaRanks {
+		card := Card(suit + pieza.rank)
+		if card == h.manilha {
+			card = Card(suit + King)
+		}
+		h.piezas[card] = pieza.envido
+		h.deckWeights[card] = 15 + i
+		h.manilhas = append(h.manilhas, card)
+	}
+}
+
+// piezaEnvido returns the envido points of cards with a pieza: the value of
+// the best pieza plus the highest of the other cards, where other piezas count
+// only their last digit. Returns -1 if there are no piezas
+func piezaEnvido(cards []Card, piezas map[Card]int) int {
+	best := -1
+	for i, c := range cards {
+		if v, ok := piezas[c]; ok && (best == -1 || v > piezas[cards[best]]) {
+			best = i
+		}
+	}
+	if best == -1 {
+		return -1
+	}
+	other := 0
+	for i, c := range cards {
+		if i != best {
+			other = max(other, piezaCardValue(c, piezas))
+		}
+	}
+	return piezas[cards[best]] + other
+}
+
+// piezaFlor returns the flor points of cards with a pieza, which counts as a
+// card of any suit: the value of the best pieza plus the other two cards.
+// Returns -1 if there are no piezas or the cards don't make a flor
+func piezaFlor(cards []Card, piezas map[Card]int) int {
+	if len(cards) != 3 {
+		return -1
+	}
+	best := -1
+	var suit byte
+	for i, c := range cards {
+		v, ok := piezas[c]
+		if !ok {
+			if suit != 0 && c[0] != suit {
+				return -1
+			}
+			suit = c[0]
+			continue
+		}
+		if best == -1 || v > piezas[cards[best]] {
+			best = i
+		}
+	}
+	if best == -1 {
+		return -1
+	}
+	points := piezas[cards[best]]
+	for i, c := range cards {
+		if i != best {
+			points += piezaCardValue(c, piezas)
+		}
+	}
+	return points
+}
+
+func piezaCardValue(card Card, piezas map[Card]int) int {
+	if v, ok := piezas[card]; ok {
+		return v % 10
+	}
+	return envidoValue(card)
+}
+
+// hasFlor returns true if the player in the position has a flor, counting the
+// piezas as cards of any suit
+func (g *Game) hasFlor(position int) bool {
+	return g.florPoints(position) != 0
+}
+
+// florPoints returns the flor points of the player in the position, 0 if they
+// don't have a flor
+func (g *Game) florPoints(position int) int {
+	player := g.players[position]
+	if points := piezaFlor(player.dealt, g.hand().piezas); points != -1 {
+		return points
+	}
+	return player.FlorPoints()
+}
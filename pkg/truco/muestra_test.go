@@ -0,0 +1,105 @@
+package truco
+
+import "testing"
+
+// uruguaioGame returns a started Uruguayan game with flor, the muestra is the
+// three of hearts
+func uruguaioGame(t *testing.T) *Game {
+	return startedGame(t, WithVariant(Uruguaio), WithFlor(true))
+}
+
+func TestMuestra(t *testing.T) {
+	g := uruguaioGame(t)
+
+	if g.Manilha() != ThreeHearts {
+		t.Fatal("wrong muestra, expected B3, instead got: " + string(g.Manilha()))
+	}
+	weights := g.hand().deckWeights
+	order := []Card{JackHearts, QueenHearts, FiveHearts, FourHearts, TwoHearts}
+	for i, c := range order {
+		if weights[c] != 15+i {
+			t.Errorf("expected %s to weigh %d, instead got: %d", c, 15+i, weights[c])
+		}
+	}
+	if weights[AceSpades] != 14 || weights[TwoSpades] != 9 {
+		t.Error("cards outside the muestra suit should keep their weights")
+	}
+	if weights[KingHearts] != ArgentinoDeckWeights()[KingHearts] {
+		t.Error("the king should not be a pieza when the muestra isn't one")
+	}
+	if g.Scores()[0] != 0 || g.HandValue() != 1 || g.MaoDeOnze() != nil {
+		t.Error("uruguayan truco should start like argentino")
+	}
+}
+
+func TestMuestraAlcahuete(t *testing.T) {
+	h := newHand()
+	h.deck = []Card{FiveClubs}
+	h.deckWeights = ArgentinoDeckWeights()
+	h.setMuestra()
+
+	if h.deckWeights[KingClubs] != 17 || h.piezas[KingClubs] != 28 {
+		t.Errorf("king should take the place of the five, instead got weight %d and envido %d",
+			h.deckWeights[KingClubs], h.piezas[KingClubs])
+	}
+	if _, ok := h.piezas[FiveClubs]; ok {
+		t.Error("the muestra should not be a pieza")
+	}
+	if h.deckWeights[FourClubs] != 18 {
+		t.Errorf("expected the four to weigh 18, instead got: %d", h.deckWeights[FourClubs])
+	}
+}
+
+func TestPiezaEnvido(t *testing.T) {
+	g := uruguaioGame(t)
+	p1 := g.players[0]
+
+	cases := []struct {
+		cards  []Card
+		points int
+	}{
+		{[]Card{TwoHearts, SevenSpades, SixClubs}, 37},
+		{[]Card{QueenHearts, KingSpades, JackClubs}, 27},
+		// the other pieza counts its last digit
+		{[]Card{TwoHearts, FourHearts, SixClubs}, 39},
+		{[]Card{SevenSpades, SixSpades, ThreeClubs}, 33},
+	}
+	for _, c := range cases {
+		setCards(p1, c.cards...)
+		if points := g.envidoPoints(0); points != c.points {
+			t.Errorf("expected %v to have %d envido points, instead got: %d", c.cards, c.points, points)
+		}
+	}
+}
+
+func TestPiezaFlor(t *testing.T) {
+	g := uruguaioGame(t)
+	p1 := g.players[0]
+	p2 := g.players[1]
+
+	cases := []struct {
+		cards  []Card
+		points int
+	}{
+		// a pieza counts as a card of any suit
+		{[]Card{FiveHearts, SevenSpades, SixSpades}, 41},
+		{[]Card{TwoHearts, FourHearts, KingClubs}, 39},
+		{[]Card{SevenSpades, SixSpades, ThreeSpades}, 36},
+		{[]Card{FiveHearts, SevenSpades, SixClubs}, 0},
+	}
+	for _, c := range cases {
+		setCards(p1, c.cards...)
+		if points := g.florPoints(0); points != c.points {
+			t.Errorf("expected %v to have %d flor points, instead got: %d", c.cards, c.points, points)
+		}
+	}
+
+	setCards(p1, FiveHearts, SevenSpades, SixSpades)
+	setCards(p2, AceClubs, TwoClubs, ThreeDiamonds)
+	if _, err := g.CallFlor(p1, Flor); err != nil {
+		t.Error("failed to call flor: " + err.Error())
+	}
+	if g.Scores()[0] != 3 {
+		t.Errorf("expected player 1 to score 3 points, instead got: %d", g.Scores()[0])
+	}
+}
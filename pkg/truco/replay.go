@@ -0,0 +1,203 @@
+package truco
+
+import (
+	"errors"
+	"slices"
+)
+
+var ErrInvalidAction = errors.New("action is not known")
+
+// actionNames are the names of the actions in saved logs
+var actionNames = map[Action]string{
+	ActionPlay:            "play",
+	ActionTruco:           "truco",
+	ActionAccept:          "accept",
+	ActionRefuse:          "refuse",
+	ActionPlayFaceDown:    "playFaceDown",
+	ActionPlayPosition:    "playPosition",
+	ActionFold:            "fold",
+	ActionAcceptMaoDeOnze: "acceptMaoDeOnze",
+	ActionRefuseMaoDeOnze: "refuseMaoDeOnze",
+	ActionCallEnvido:      "callEnvido",
+	ActionAnnounceEnvido:  "announceEnvido",
+	ActionConcedeEnvido:   "concedeEnvido",
+	ActionCallFlor:        "callFlor",
+	ActionCut:             "cut",
+}
+
+func (a Action) MarshalText() ([]byte, error) {
+	return marshalName(actionNames, a, ErrInvalidAction)
+}
+
+func (a *Action) UnmarshalText(text []byte) error {
+	return unmarshalName(actionNames, text, a, ErrInvalidAction)
+}
+
+// Move is an action made by a player, with everything needed to make it again
+type Move struct {
+	Action Action `json:"action"`
+	// position of the player who made the move
+	Player int `json:"player"`
+	// card played with ActionPlay and ActionPlayFaceDown
+	Card Card `json:"card"`
+	// card position for ActionPlayPosition, cut position for ActionCut
+	Position int `json:"position"`
+	// bet of ActionCallEnvido
+	Envido EnvidoCall `json:"envido"`
+	// bet of ActionCallFlor
+	Flor FlorCall `json:"flor"`
+	// choices of ActionCut
+	Choices []CutChoice `json:"choices"`
+}
+
+// LogPlayer identifies a player of a logged game
+type LogPlayer struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Log is everything needed to rebuild a game: its rules and settings, its
+// seeds and the moves made so far
+type Log struct {
+	ID         string      `json:"id"`
+	Players    []LogPlayer `json:"players"`
+	MaxPlayers int         `json:"maxPlayers"`
+	Rules      RuleSet     `json:"rules"`
+	Seed1      uint64      `json:"seed1"`
+	Seed2      uint64      `json:"seed2"`
+	PeRotation bool        `json:"peRotation"`
+	// position of the dealer of the first hand, -1 = last position
+	Dealer int  `json:"dealer"`
+	Cut    bool `json:"cut"`
+	// score of each team before the first hand
+	StartingScores []int  `json:"startingScores"`
+	Moves          []Move `json:"moves"`
+}
+
+// Log returns the log of the game, random seeds are only chosen when the game
+// starts
+func (g *Game) Log() Log {
+	players := make([]LogPlayer, len(g.players))
+	for i, p := range g.players {
+		players[i] = LogPlayer{ID: p.id, Name: p.name}
+	}
+	moves := make([]Move, len(g.moves))
+	for i, m := range g.moves {
+		m.Choices = slices.Clone(m.Choices)
+		moves[i] = m
+	}
+	return Log{
+		ID:             g.id,
+		Players:        players,
+		MaxPlayers:     g.maxPlayers,
+		Rules:          g.rules.clone(),
+		Seed1:          g.seed1,
+		Seed2:          g.seed2,
+		PeRotation:     g.peRotation,
+		Dealer:         g.dealer,
+		Cut:            g.cut,
+		StartingScores: slices.Clone(g.startingScore),
+		Moves:          moves,
+	}
+}
+
+// Replay rebuilds a started game from its log, making every move again. The
+// players are created again with the same IDs and names
+func Replay(log Log) (*Game, error) {
+	g, err := newGameFromLog(log)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := g.Start(); err != nil {
+		return nil, err
+	}
+	for _, m := range log.Moves {
+		if _, err := g.Apply(m); err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
+}
+
+// newGameFromLog creates the game of the log with its players and settings,
+// before it starts
+func newGameFromLog(log Log) (*Game, error) {
+	g, err := NewGame(
+		WithMaxPlayers(log.MaxPlayers),
+		WithRules(log.Rules),
+		WithSeed(log.Seed1, log.Seed2),
+		WithPeRotation(log.PeRotation),
+		WithCut(log.Cut),
+	)
+	if err != nil {
+		return nil, err
+	}
+	g.id = log.ID
+	for _, p := range log.Players {
+		if err := g.AddPlayer(&Player{id: p.ID, name: p.Name, cards: make([]Card, 0)}); err != nil {
+			return nil, err
+		}
+	}
+	if log.Dealer != -1 {
+		if log.Dealer < 0 || log.Dealer >= len(g.players) {
+			return nil, ErrPlayerNotFound
+		}
+		if err := g.SetDealer(g.players[log.Dealer]); err != nil {
+			return nil, err
+		}
+	}
+	for team, score := range log.StartingScores {
+		if err := g.SetStartingScore(team, score); err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
+}
+
+// Apply makes the move, calling the action it describes
+func (g *Game) Apply(m Move) ([]Event, error) {
+	if m.Player < 0 || m.Player >= len(g.players) {
+		return nil, ErrPlayerNotFound
+	}
+	player := g.players[m.Player]
+	switch m.Action {
+	case ActionPlay:
+		return g.Play(player, m.Card)
+	case ActionPlayFaceDown:
+		return g.PlayFaceDown(player, m.Card)
+	case ActionPlayPosition:
+		return g.PlayPosition(player, m.Position)
+	case ActionTruco:
+		return g.Truco(player)
+	case ActionAccept:
+		return g.Accept(player)
+	case ActionRefuse:
+		return g.Refuse(player)
+	case ActionFold:
+		return g.Fold(player)
+	case ActionAcceptMaoDeOnze:
+		return g.AcceptMaoDeOnze(player)
+	case ActionRefuseMaoDeOnze:
+		return g.RefuseMaoDeOnze(player)
+	case ActionCallEnvido:
+		return g.CallEnvido(player, m.Envido)
+	case ActionAnnounceEnvido:
+		return g.AnnounceEnvido(player)
+	case ActionConcedeEnvido:
+		return g.ConcedeEnvido(player)
+	case ActionCallFlor:
+		return g.CallFlor(player, m.Flor)
+	case ActionCut:
+		return g.Cut(player, m.Position, m.Choices...)
+	}
+	return nil, ErrInvalidAction
+}
+
+// record keeps the move in the log if the action succeeded and returns the
+// events of the action
+func (g *Game) record(move Move, err error) ([]Event, error) {
+	if err == nil {
+		g.moves = append(g.moves, move)
+	}
+	return g.flush(err)
+}
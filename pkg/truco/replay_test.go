@@ -0,0 +1,95 @@
+package truco
+
+import (
+	"reflect"
+	"testing"
+)
+
+// playMoves plays the first card of the current player, calling truco at the
+// start of every other hand, until the game has the number of moves
+func playMoves(t *testing.T, g *Game, moves int) {
+	for len(g.moves) < moves && g.Running() {
+		cp := g.CurrentPlayer()
+		var err error
+		switch {
+		case g.hand().phase == phaseMaoDeOnze:
+			_, err = g.AcceptMaoDeOnze(g.MaoDeOnze())
+		case g.hand().call != nil:
+			_, err = g.Accept(g.players[g.position(cp)^1])
+		case len(g.hand().pile) == 0 && len(g.hands)%2 == 0 && g.hand().stake == 0 && g.hand().maoDeOnze == -1:
+			_, err = g.Truco(cp)
+		default:
+			_, err = g.PlayPosition(cp, 0)
+		}
+		if err != nil {
+			t.Fatal("failed to make move: " + err.Error())
+		}
+	}
+}
+
+// TestReplaySeeds checks that an unseeded game keeps the random seeds it picks
+// when it starts, so it can be replayed
+func TestReplaySeeds(t *testing.T) {
+	g := startedGame(t, WithSeed(0, 0))
+	seed1, seed2 := g.seed1, g.seed2
+	if seed1 == 0 || seed2 == 0 {
+		t.Fatal("random seeds should be kept when the game starts")
+	}
+	if err := g.Seed(1, 2); err != ErrGameRunning {
+		t.Errorf("expected error ErrGameRunning, instead got: %v", err)
+	}
+	playMoves(t, g, 10)
+	if g.seed1 != seed1 || g.seed2 != seed2 {
+		t.Error("seeds should not change between hands")
+	}
+}
+
+func TestReplay(t *testing.T) {
+	g := startedGame(t, WithStartingScores(1, 3))
+	playMoves(t, g, 30)
+
+	log := g.Log()
+	if len(log.Moves) != 30 {
+		t.Fatalf("expected 30 moves in the log, instead got: %d", len(log.Moves))
+	}
+	replayed, err := Replay(log)
+	if err != nil {
+		t.Fatal("failed to replay game: " + err.Error())
+	}
+	if replayed.id != g.id || !reflect.DeepEqual(replayed.score, g.score) {
+		t.Errorf("replayed game should have the same id and score, expected %v, instead got: %v", g.score, replayed.score)
+	}
+	if !reflect.DeepEqual(replayed.hands, g.hands) {
+		t.Error("replayed game should have the same hands")
+	}
+	if !reflect.DeepEqual(replayed.players, g.players) {
+		t.Error("replayed game should have the same players and cards")
+	}
+	if !reflect.DeepEqual(replayed.Log(), log) {
+		t.Error("replayed game should have the same log")
+	}
+
+	log.Moves = append(log.Moves, Move{Action: ActionPlay, Player: 0, Card: CardBack})
+	if _, err := Replay(log); err != ErrNotPlayerTurn && err != ErrPlayerDoesNotHaveCard {
+		t.Errorf("expected an error replaying an invalid move, instead got: %v", err)
+	}
+}
+
+func TestReplayCut(t *testing.T) {
+	g := cutGame(t)
+	if _, err := g.Cut(g.players[0], 5, CutBater, CutFromBottom); err != nil {
+		t.Fatal("failed to cut the deck: " + err.Error())
+	}
+	playMoves(t, g, 4)
+
+	replayed, err := Replay(g.Log())
+	if err != nil {
+		t.Fatal("failed to replay game: " + err.Error())
+	}
+	if !reflect.DeepEqual(replayed.hands, g.hands) {
+		t.Error("replayed game should have the same hands")
+	}
+	if _, err := replayed.Apply(Move{Action: Action(-1)}); err != ErrInvalidAction {
+		t.Errorf("expected error ErrInvalidAction, instead got: %v", err)
+	}
+}
@@ -0,0 +1,243 @@
+package truco
+
+import (
+	"errors"
+	"maps"
+	"slices"
+)
+
+var ErrInvalidRuleSet = errors.New("rule set needs a deck with weights for every card, stakes, a target score and envido to play flor")
+
+// ManilhaMode is how the manilhas, the cards that beat the regular order, are
+// chosen each hand
+type ManilhaMode int
+
+const (
+	// ManilhaFixed has no card turned up, RuleSet.Manilhas beat every other
+	// card if there are any
+	ManilhaFixed ManilhaMode = iota
+	// ManilhaVira turns up a card (vira) and the next rank is the manilha, its
+	// suits are ordered by RuleSet.ManilhaSuits
+	ManilhaVira
+	// ManilhaMuestra turns up a card (muestra) and the piezas of its suit beat
+	// every other card
+	ManilhaMuestra
+)
+
+// manilhaModeNames are the names of the manilha modes in saved games
+var manilhaModeNames = map[ManilhaMode]string{
+	ManilhaFixed:   "fixed",
+	ManilhaVira:    "vira",
+	ManilhaMuestra: "muestra",
+}
+
+func (m ManilhaMode) MarshalText() ([]byte, error) {
+	return marshalName(manilhaModeNames, m, ErrUnknownName)
+}
+
+func (m *ManilhaMode) UnmarshalText(text []byte) error {
+	return unmarshalName(manilhaModeNames, text, m, ErrUnknownName)
+}
+
+// RuleSet holds the rules a game is played with
+type RuleSet struct {
+	// ranks and suits in the deck
+	Deck Deck `json:"deck"`
+	// weight of each card in the deck, higher wins the round
+	DeckWeights DeckWeights `json:"deckWeights"`
+	// how the manilhas are chosen
+	Manilha ManilhaMode `json:"manilha"`
+	// suits of the manilha from the lowest to the highest, used by ManilhaVira
+	ManilhaSuits []string `json:"manilhaSuits"`
+	// fixed manilhas from the lowest to the highest, used by ManilhaFixed
+	Manilhas []Card `json:"manilhas"`
+	// value of a hand for each betting level, starting with no call
+	Stakes []int `json:"stakes"`
+	// points needed to win the game
+	TargetScore int `json:"targetScore"`
+	// how tied rounds decide the hand
+	TieRule TieRule `json:"tieRule"`
+	// the side one hand away from winning decides if the hand is played
+	MaoDeOnze bool `json:"maoDeOnze"`
+	// envido is played alongside the cards
+	Envido bool `json:"envido"`
+	// calls allowed in the envido bet, every call if empty
+	EnvidoCalls []EnvidoCall `json:"envidoCalls"`
+	// players can sing flor, only with envido
+	Flor bool `json:"flor"`
+}
+
+// validate checks if a game can be played with the rules
+func (r RuleSet) validate() error {
+	if len(r.Deck.Cards()) == 0 || len(r.Stakes) == 0 || r.TargetScore <= 0 {
+		return ErrInvalidRuleSet
+	}
+	for _, c := range r.Deck.Cards() {
+		if _, ok := r.DeckWeights[c]; !ok {
+			return ErrInvalidRuleSet
+		}
+	}
+	if r.Manilha == ManilhaVira && len(r.ManilhaSuits) == 0 {
+		return ErrInvalidRuleSet
+	}
+	if r.Flor && !r.Envido {
+		return ErrInvalidRuleSet
+	}
+	for _, c := range r.Manilhas {
+		if _, ok := r.DeckWeights[c]; !ok {
+			return ErrInvalidRuleSet
+		}
+	}
+	return nil
+}
+
+// clone returns a copy of the rules that doesn't share slices or maps
+func (r RuleSet) clone() RuleSet {
+	r.Deck.Ranks = slices.Clone(r.Deck.Ranks)
+	r.Deck.Suits = slices.Clone(r.Deck.Suits)
+	r.DeckWeights = maps.Clone(r.DeckWeights)
+	r.ManilhaSuits = slices.Clone(r.ManilhaSuits)
+	r.Manilhas = slices.Clone(r.Manilhas)
+	r.Stakes = slices.Clone(r.Stakes)
+	r.EnvidoCalls = slices.Clone(r.EnvidoCalls)
+	return r
+}
+
+// Option changes a setting of a new game. The options that choose the rule
+// set go first, then the ones changing a single setting and last the starting
+// scores, so the order they are passed in doesn't matter
+type Option struct {
+	stage optionStage
+	apply func(g *Game) error
+}
+
+type optionStage int
+
+const (
+	// replaces the whole rule set
+	stageRules optionStage = iota
+	// changes one setting or rule
+	stageSettings
+	// checked against the target score
+	stageScores
+)
+
+// WithRules plays the game with the rule set, the other options change it
+// whichever order they are passed in
+func WithRules(rules RuleSet) Option {
+	return Option{stage: stageRules, apply: func(g *Game) error {
+		return g.SetRules(rules)
+	}}
+}
+
+// WithVariant plays the game with the rules of a regional variant, the other
+// options change them whichever order they are passed in
+func WithVariant(variant Variant) Option {
+	return Option{stage: stageRules, apply: func(g *Game) error {
+		return g.SetVariant(variant)
+	}}
+}
+
+// WithMaxPlayers changes how many players the game has, see Game.SetMaxPlayers
+func WithMaxPlayers(maxPlayers int) Option {
+	return Option{stage: stageSettings, apply: func(g *Game) error {
+		return g.SetMaxPlayers(maxPlayers)
+	}}
+}
+
+// WithTargetScore changes how many points a team needs to win the game
+func WithTargetScore(target int) Option {
+	return Option{stage: stageSettings, apply: func(g *Game) error {
+		return g.SetTargetScore(target)
+	}}
+}
+
+// WithStartingScores gives each team a handicap, see Game.SetStartingScore
+func WithStartingScores(team0, team1 int) Option {
+	return Option{stage: stageScores, apply: func(g *Game) error {
+		if err := g.SetStartingScore(0, team0); err != nil {
+			return err
+		}
+		return g.SetStartingScore(1, team1)
+	}}
+}
+
+// WithManilhaMode chooses how the manilhas are chosen, see Game.SetManilhaMode
+func WithManilhaMode(mode ManilhaMode) Option {
+	return Option{stage: stageSettings, apply: func(g *Game) error {
+		return g.SetManilhaMode(mode)
+	}}
+}
+
+// WithTieRule chooses how tied rounds decide who wins the hand
+func WithTieRule(rule TieRule) Option {
+	return Option{stage: stageSettings, apply: func(g *Game) error {
+		return g.SetTieRule(rule)
+	}}
+}
+
+// WithFlor enables flor for rules played with envido
+func WithFlor(enabled bool) Option {
+	return Option{stage: stageSettings, apply: func(g *Game) error {
+		return g.SetFlor(enabled)
+	}}
+}
+
+// WithPeRotation enables the head-to-head hands of 6 player games, see
+// Game.SetPeRotation
+func WithPeRotation(enabled bool) Option {
+	return Option{stage: stageSettings, apply: func(g *Game) error {
+		return g.SetPeRotation(enabled)
+	}}
+}
+
+// WithCut makes the player before the dealer cut the deck before each hand
+func WithCut(enabled bool) Option {
+	return Option{stage: stageSettings, apply: func(g *Game) error {
+		return g.SetCut(enabled)
+	}}
+}
+
+// WithSeed seeds the random number generator that shuffles the deck
+func WithSeed(seed1, seed2 uint64) Option {
+	return Option{stage: stageSettings, apply: func(g *Game) error {
+		return g.Seed(seed1, seed2)
+	}}
+}
+
+// SetRules chooses the rules the game is played with
+func (g *Game) SetRules(rules RuleSet) error {
+	if g.running {
+		return ErrGameRunning
+	}
+	if err := rules.validate(); err != nil {
+		return err
+	}
+	for _, score := range g.score {
+		if rules.TargetScore <= score {
+			return ErrInvalidTargetScore
+		}
+	}
+	g.rules = rules.clone()
+	return nil
+}
+
+// SetManilhaMode changes how the manilhas are chosen keeping the rest of the
+// rules. Fixed manilhas without a set of their own are the manilhas velhas
+// (4♣, 7♥, A♠ and 7♦)
+func (g *Game) SetManilhaMode(mode ManilhaMode) error {
+	if g.running {
+		return ErrGameRunning
+	}
+	rules := g.rules.clone()
+	rules.Manilha = mode
+	if mode == ManilhaFixed && len(rules.Manilhas) == 0 {
+		rules.Manilhas = MineiroManilhas()
+	}
+	return g.SetRules(rules)
+}
+
+// Rules returns the rules the game is played with
+func (g *Game) Rules() RuleSet {
+	return g.rules.clone()
+}
@@ -0,0 +1,214 @@
+package truco
+
+import "testing"
+
+func TestNewGameOptions(t *testing.T) {
+	g, err := NewGame(WithVariant(Mineiro), WithMaxPlayers(4), WithTieRule(TieNextRound), WithSeed(123, 456))
+	if err != nil {
+		t.Fatal("failed to create game: " + err.Error())
+	}
+	if g.maxPlayers != 4 || g.seed1 != 123 || g.seed2 != 456 {
+		t.Error("options should change the game settings")
+	}
+	rules := g.Rules()
+	if rules.Manilha != ManilhaFixed || rules.Stakes[0] != 2 || rules.TieRule != TieNextRound {
+		t.Errorf("expected the Mineiro rules with the next round tie rule, instead got: %+v", rules)
+	}
+	// the returned rules are a copy
+	rules.Stakes[0] = 5
+	if g.rules.Stakes[0] != 2 {
+		t.Error("changing the returned rules should not change the game")
+	}
+
+	if _, err := NewGame(WithMaxPlayers(3)); err != ErrInvalidPlayerCount {
+		t.Errorf("expected error ErrInvalidPlayerCount, instead got: %v", err)
+	}
+	if _, err := NewGame(WithRules(RuleSet{Deck: CleanDeck(), Stakes: DefaultStakes(), TargetScore: 12})); err != ErrInvalidRuleSet {
+		t.Errorf("expected error ErrInvalidRuleSet, instead got: %v", err)
+	}
+}
+
+func TestOptionsOrder(t *testing.T) {
+	// the variant is applied before the other options wherever it is passed
+	g, err := NewGame(WithFlor(true), WithTieRule(TieNextRound), WithTargetScore(40), WithVariant(Argentino))
+	if err != nil {
+		t.Fatal("failed to create game: " + err.Error())
+	}
+	rules := g.Rules()
+	if !rules.Envido || !rules.Flor || rules.TieRule != TieNextRound || rules.TargetScore != 40 {
+		t.Errorf("expected the Argentino rules with flor, the next round tie rule and 40 points, instead got: %+v", rules)
+	}
+	// the starting scores are checked against the new target score
+	g, err = NewGame(WithStartingScores(15, 0), WithTargetScore(20))
+	if err != nil {
+		t.Fatal("failed to create game: " + err.Error())
+	}
+	if g.score[0] != 15 || g.rules.TargetScore != 20 {
+		t.Errorf("expected score 15 of 20, instead got: %d of %d", g.score[0], g.rules.TargetScore)
+	}
+
+	if _, err := NewGame(WithFlor(true)); err != ErrFlorNotAllowed {
+		t.Errorf("expected error ErrFlorNotAllowed, instead got: %v", err)
+	}
+	rules = Paulista.Rules()
+	rules.Flor = true
+	if _, err := NewGame(WithRules(rules)); err != ErrInvalidRuleSet {
+		t.Errorf("expected error ErrInvalidRuleSet, instead got: %v", err)
+	}
+}
+
+func TestCustomRules(t *testing.T) {
+	rules := Paulista.Rules()
+	rules.ManilhaSuits = []string{Spades, Hearts, Diamonds, Clubs}
+	rules.Stakes = []int{2, 4}
+	g := startedGame(t, WithRules(rules))
+	// the game keeps its own copy of the rules
+	rules.Stakes[0] = 1
+	if err := g.SetRules(rules); err != ErrGameRunning {
+		t.Errorf("expected error ErrGameRunning, instead got: %v", err)
+	}
+
+	// vira is a three, the four of clubs is now the highest manilha
+	if g.hand().deckWeights[FourClubs] != 14 || g.hand().deckWeights[FourSpades] != 11 {
+		t.Error("manilhas should follow the suit order of the rules")
+	}
+	if g.HandValue() != 2 {
+		t.Errorf("expected hand value to be 2, instead got: %d", g.HandValue())
+	}
+	if _, err := g.Truco(g.players[0]); err != nil {
+		t.Error("failed to call truco: " + err.Error())
+	}
+	if _, err := g.Truco(g.players[1]); err != ErrCannotRaise {
+		t.Errorf("expected error ErrCannotRaise, instead got: %v", err)
+	}
+}
+
+func TestMatchOptions(t *testing.T) {
+	if _, err := NewMatch(3, WithMaxPlayers(5)); err != ErrInvalidPlayerCount {
+		t.Errorf("expected error ErrInvalidPlayerCount, instead got: %v", err)
+	}
+	m, err := NewMatch(1, WithVariant(Argentino), WithMaxPlayers(4))
+	if err != nil {
+		t.Fatal("failed to create match: " + err.Error())
+	}
+	for _, name := range []string{"player 1", "player 2", "player 3", "player 4"} {
+		p, err := NewPlayer(name)
+		if err != nil {
+			t.Fatal("failed to create player: " + err.Error())
+		}
+		if err := m.AddPlayer(p); err != nil {
+			t.Fatal("failed to add player: " + err.Error())
+		}
+	}
+	if err := m.Start(); err != nil {
+		t.Fatal("failed to start match: " + err.Error())
+	}
+	if !m.Game().rules.Envido || m.Game().rules.TargetScore != 30 {
+		t.Error("games of the match should use the Argentino rules")
+	}
+}
+
+func TestTargetScore(t *testing.T) {
+	g, err := NewGame(WithTargetScore(24), WithSeed(123, 456))
+	if err != nil {
+		t.Fatal("failed to create game: " + err.Error())
+	}
+	for _, name := range []string{"player 1", "player 2"} {
+		p, err := NewPlayer(name)
+		if err != nil {
+			t.Fatal("failed to create player: " + err.Error())
+		}
+		if err := g.AddPlayer(p); err != nil {
+			t.Fatal("failed to add player: " + err.Error())
+		}
+	}
+	if err := g.SetStartingScore(1, 22); err != nil {
+		t.Fatal("failed to set starting score: " + err.Error())
+	}
+	if err := g.SetTargetScore(22); err != ErrInvalidTargetScore {
+		t.Errorf("expected error ErrInvalidTargetScore, instead got: %v", err)
+	}
+	if _, err := g.Start(); err != nil {
+		t.Fatal("failed to start game: " + err.Error())
+	}
+	p1 := g.players[0]
+	p2 := g.players[1]
+
+	if g.MaoDeOnze() != nil {
+		t.Error("22 points should not be a mão de onze when playing to 24")
+	}
+	if _, err := g.Fold(p1); err != nil {
+		t.Fatal("failed to fold: " + err.Error())
+	}
+	// mão de onze at 23 points
+	if g.MaoDeOnze() != p2 {
+		t.Fatal("player 2 should be deciding the mão de onze at 23 points")
+	}
+	if _, err := g.AcceptMaoDeOnze(p2); err != nil {
+		t.Fatal("failed to accept mão de onze: " + err.Error())
+	}
+	if _, err := g.Fold(p2); err != nil {
+		t.Fatal("failed to fold: " + err.Error())
+	}
+	if !g.Running() {
+		t.Error("game should still be running before a team reaches 24 points")
+	}
+}
+
+func TestStartingScore(t *testing.T) {
+	g, err := NewGame(WithStartingScores(-3, 5))
+	if err != nil {
+		t.Fatal("failed to create game: " + err.Error())
+	}
+	if g.Scores()[0] != -3 || g.Scores()[1] != 5 {
+		t.Errorf("expected score to be [-3 5], instead got: %v", g.Scores())
+	}
+	if err := g.SetStartingScore(2, 0); err != ErrInvalidTeam {
+		t.Errorf("expected error ErrInvalidTeam, instead got: %v", err)
+	}
+	if err := g.SetStartingScore(0, 12); err != ErrInvalidStartingScore {
+		t.Errorf("expected error ErrInvalidStartingScore, instead got: %v", err)
+	}
+	if _, err := NewGame(WithStartingScores(0, 12)); err != ErrInvalidStartingScore {
+		t.Errorf("expected error ErrInvalidStartingScore, instead got: %v", err)
+	}
+}
+
+func TestManilhaVelha(t *testing.T) {
+	g := startedGame(t, WithManilhaMode(ManilhaFixed))
+	if err := g.SetManilhaMode(ManilhaVira); err != ErrGameRunning {
+		t.Errorf("expected error ErrGameRunning, instead got: %v", err)
+	}
+
+	if g.Manilha() != "" {
+		t.Error("there should be no vira with manilha velha, instead got: " + string(g.Manilha()))
+	}
+	manilhas := g.Manilhas()
+	expected := []Card{SevenDiamonds, AceSpades, SevenHearts, FourClubs}
+	if len(manilhas) != len(expected) {
+		t.Fatalf("expected manilhas %v, instead got: %v", expected, manilhas)
+	}
+	for i, c := range expected {
+		if manilhas[i] != c || g.hand().deckWeights[c] != 11+i {
+			t.Errorf("expected %s to be manilha with weight %d, instead got: %d", c, 11+i, g.hand().deckWeights[c])
+		}
+	}
+	// the rest of the Paulista rules stay the same
+	if g.HandValue() != 1 || g.hand().deckWeights[FourHearts] != 1 {
+		t.Error("manilha velha should keep the Paulista stakes and weights")
+	}
+}
+
+func TestManilhasVira(t *testing.T) {
+	g := startedGame(t)
+	manilhas := g.Manilhas()
+	expected := []Card{FourClubs, FourDiamonds, FourHearts, FourSpades}
+	if len(manilhas) != len(expected) {
+		t.Fatalf("expected manilhas %v, instead got: %v", expected, manilhas)
+	}
+	for i, c := range expected {
+		if manilhas[i] != c {
+			t.Errorf("expected manilha %s, instead got: %s", c, manilhas[i])
+		}
+	}
+}
@@ -0,0 +1,509 @@
+package truco
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"maps"
+	"slices"
+)
+
+var (
+	ErrInvalidSave        = errors.New("saved game is not valid")
+	ErrUnknownSaveVersion = errors.New("saved game version is not known")
+	ErrUnknownName        = errors.New("value has no saved name")
+)
+
+// saveVersion is the version of the format written by MarshalJSON:
+//
+//   - 1: the log of the game with actions saved as numbers
+//   - 2: the log with actions saved by name and the full state of the game
+//   - 3: tie rules, manilha modes, envido and flor calls, cut choices and hand
+//     phases saved by name
+const saveVersion = 3
+
+// migrations upgrade a save to the next version, by the version they upgrade
+// from. Saves are migrated one version at a time until they are current
+var migrations = map[int]func(saved map[string]any) error{
+	1: migrateV1,
+	2: migrateV2,
+}
+
+type savedGame struct {
+	Version int         `json:"version"`
+	Log     Log         `json:"log"`
+	State   *savedState `json:"state,omitempty"`
+}
+
+type savedState struct {
+	Running bool         `json:"running"`
+	Score   []int        `json:"score"`
+	Hands   []savedHand  `json:"hands"`
+	Players []savedCards `json:"players"`
+}
+
+type savedCards struct {
+	Cards []Card `json:"cards"`
+	Dealt []Card `json:"dealt"`
+}
+
+type savedHand struct {
+	Deck          []Card       `json:"deck"`
+	Manilha       Card         `json:"manilha"`
+	Manilhas      []Card       `json:"manilhas"`
+	Pile          []savedCard  `json:"pile"`
+	DeckWeights   map[Card]int `json:"deckWeights"`
+	Points        []int        `json:"points"`
+	RoundWinners  []int        `json:"roundWinners"`
+	Round         uint         `json:"round"`
+	WonTeam       int          `json:"wonTeam"`
+	DeckPosition  uint         `json:"deckPosition"`
+	Dealer        int          `json:"dealer"`
+	Seats         []int        `json:"seats"`
+	CurrentPlayer uint         `json:"currentPlayer"`
+	Stake         int          `json:"stake"`
+	RaisedBy      int          `json:"raisedBy"`
+	Call          *savedCall   `json:"call,omitempty"`
+	Phase         phase        `json:"phase"`
+	MaoDeOnze     int          `json:"maoDeOnze"`
+	MaoDeFerro    bool         `json:"maoDeFerro"`
+	Envido        *savedEnvido `json:"envido,omitempty"`
+	Flor          *savedFlor   `json:"flor,omitempty"`
+	Piezas        map[Card]int `json:"piezas,omitempty"`
+}
+
+type savedCard struct {
+	Card     Card `json:"card"`
+	Position int  `json:"position"`
+	FaceDown bool `json:"faceDown"`
+}
+
+type savedCall struct {
+	Position int `json:"position"`
+	Stake    int `json:"stake"`
+}
+
+type savedEnvido struct {
+	Calls         []EnvidoCall        `json:"calls"`
+	Position      int                 `json:"position"`
+	Pending       bool                `json:"pending"`
+	Announcements []savedAnnouncement `json:"announcements"`
+	Best          int                 `json:"best"`
+	BestPoints    int                 `json:"bestPoints"`
+	Done          bool                `json:"done"`
+}
+
+type savedAnnouncement struct {
+	// position of the player who spoke
+	Player   int  `json:"player"`
+	Points   int  `json:"points"`
+	Conceded bool `json:"conceded"`
+}
+
+type savedFlor struct {
+	Calls    []FlorCall `json:"calls"`
+	Position int        `json:"position"`
+	Pending  bool       `json:"pending"`
+	Done     bool       `json:"done"`
+}
+
+// MarshalJSON saves the game with its log and full state, the functions
+// registered with Subscribe and the match the game belongs to are not saved
+func (g *Game) MarshalJSON() ([]byte, error) {
+	state := savedState{
+		Running: g.running,
+		Score:   slices.Clone(g.score),
+		Hands:   make([]savedHand, len(g.hands)),
+		Players: make([]savedCards, len(g.players)),
+	}
+	for i, h := range g.hands {
+		state.Hands[i] = g.saveHand(h)
+	}
+	for i, p := range g.players {
+		state.Players[i] = savedCards{Cards: slices.Clone(p.cards), Dealt: slices.Clone(p.dealt)}
+	}
+	return json.Marshal(savedGame{Version: saveVersion, Log: g.Log(), State: &state})
+}
+
+// UnmarshalJSON loads a game saved by MarshalJSON. Games saved with an older
+// version are migrated first, version 1 games are rebuilt by replaying their
+// log as they have no state
+func (g *Game) UnmarshalJSON(data []byte) error {
+	data, version, err := migrate(data)
+	if err != nil {
+		return err
+	}
+	var saved savedGame
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return err
+	}
+	var loaded *Game
+	if version == 1 {
+		loaded, err = Replay(saved.Log)
+	} else {
+		loaded, err = loadState(saved.Log, saved.State)
+	}
+	if err != nil {
+		return err
+	}
+	*g = *loaded
+	return nil
+}
+
+// migrate upgrades a save to the current version, it returns the migrated save
+// and the version it was saved with
+func migrate(data []byte) ([]byte, int, error) {
+	var saved map[string]any
+	// numbers are kept as they are, seeds don't fit in a float64
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&saved); err != nil {
+		return nil, 0, err
+	}
+	number, ok := saved["version"].(json.Number)
+	if !ok {
+		return nil, 0, ErrUnknownSaveVersion
+	}
+	version, err := number.Int64()
+	if err != nil || version < 1 || version > saveVersion {
+		return nil, 0, ErrUnknownSaveVersion
+	}
+	if version == saveVersion {
+		return data, int(version), nil
+	}
+	for v := int(version); v < saveVersion; v++ {
+		if err := migrations[v](saved); err != nil {
+			return nil, 0, err
+		}
+		saved["version"] = v + 1
+	}
+	data, err = json.Marshal(saved)
+	return data, int(version), err
+}
+
+// v1ActionNames are the names of the actions by their number in version 1
+var v1ActionNames = []string{
+	"play", "truco", "accept", "refuse", "playFaceDown", "playPosition", "fold",
+	"acceptMaoDeOnze", "refuseMaoDeOnze", "callEnvido", "announceEnvido",
+	"concedeEnvido", "callFlor", "cut",
+}
+
+// migrateV1 saves the actions of the moves by name
+func migrateV1(saved map[string]any) error {
+	for _, move := range savedMoves(saved) {
+		if err := renameNumber(move, "action", v1ActionNames); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateV2 saves the tie rule, the manilha mode, the envido and flor calls, the
+// cut choices and the hand phases by name, with the numbers they had in version 2
+func migrateV2(saved map[string]any) error {
+	envidoCalls := []string{"envido", "realEnvido", "faltaEnvido"}
+	florCalls := []string{"flor", "contraFlor", "contraFlorAlResto"}
+
+	log, _ := saved["log"].(map[string]any)
+	rules, _ := log["rules"].(map[string]any)
+	if rules == nil {
+		return ErrInvalidSave
+	}
+	if err := renameNumber(rules, "manilha", []string{"fixed", "vira", "muestra"}); err != nil {
+		return err
+	}
+	if err := renameNumber(rules, "tieRule", []string{"firstRound", "nextRound", "nobodyScores"}); err != nil {
+		return err
+	}
+	if err := renameNumbers(rules, "envidoCalls", envidoCalls); err != nil {
+		return err
+	}
+	for _, move := range savedMoves(saved) {
+		if err := renameNumber(move, "envido", envidoCalls); err != nil {
+			return err
+		}
+		if err := renameNumber(move, "flor", florCalls); err != nil {
+			return err
+		}
+		if err := renameNumbers(move, "choices", []string{"bater", "fromBottom"}); err != nil {
+			return err
+		}
+	}
+
+	// version 1 saves have no state
+	state, _ := saved["state"].(map[string]any)
+	hands, _ := state["hands"].([]any)
+	for _, h := range hands {
+		hand, ok := h.(map[string]any)
+		if !ok {
+			return ErrInvalidSave
+		}
+		if err := renameNumber(hand, "phase", []string{"playing", "maoDeOnze", "cut"}); err != nil {
+			return err
+		}
+		if envido, ok := hand["envido"].(map[string]any); ok {
+			if err := renameNumbers(envido, "calls", envidoCalls); err != nil {
+				return err
+			}
+		}
+		if flor, ok := hand["flor"].(map[string]any); ok {
+			if err := renameNumbers(flor, "calls", florCalls); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// savedMoves returns the moves in the log of a save being migrated
+func savedMoves(saved map[string]any) []map[string]any {
+	log, _ := saved["log"].(map[string]any)
+	list, _ := log["moves"].([]any)
+	moves := make([]map[string]any, 0, len(list))
+	for _, m := range list {
+		if move, ok := m.(map[string]any); ok {
+			moves = append(moves, move)
+		}
+	}
+	return moves
+}
+
+// renameNumber replaces the number saved in a field with its name
+func renameNumber(saved map[string]any, field string, names []string) error {
+	name, err := numberName(saved[field], names)
+	if err != nil {
+		return err
+	}
+	saved[field] = name
+	return nil
+}
+
+// renameNumbers replaces the numbers saved in a list with their names, the list
+// can be missing
+func renameNumbers(saved map[string]any, field string, names []string) error {
+	list, _ := saved[field].([]any)
+	for i, number := range list {
+		name, err := numberName(number, names)
+		if err != nil {
+			return err
+		}
+		list[i] = name
+	}
+	return nil
+}
+
+// numberName returns the name of a saved number
+func numberName(saved any, names []string) (string, error) {
+	number, ok := saved.(json.Number)
+	if !ok {
+		return "", ErrInvalidSave
+	}
+	i, err := number.Int64()
+	if err != nil || i < 0 || i >= int64(len(names)) {
+		return "", ErrInvalidSave
+	}
+	return names[i], nil
+}
+
+func loadState(log Log, state *savedState) (*Game, error) {
+	if state == nil || len(state.Hands) == 0 || len(state.Players) != len(log.Players) || len(state.Score) != 2 {
+		return nil, ErrInvalidSave
+	}
+	g, err := newGameFromLog(log)
+	if err != nil {
+		return nil, err
+	}
+	g.running = state.Running
+	g.score = slices.Clone(state.Score)
+	g.moves = log.Moves
+	// the hands of a started game have been dealt
+	dealt := state.Running || len(log.Moves) > 0
+	g.hands = make([]*Hand, len(state.Hands))
+	for i, h := range state.Hands {
+		if g.hands[i], err = g.loadHand(h, dealt); err != nil {
+			return nil, err
+		}
+	}
+	for i, p := range g.players {
+		p.cards = slices.Clone(state.Players[i].Cards)
+		p.dealt = slices.Clone(state.Players[i].Dealt)
+	}
+	return g, nil
+}
+
+func (g *Game) saveHand(h *Hand) savedHand {
+	saved := savedHand{
+		Deck:          slices.Clone(h.deck),
+		Manilha:       h.manilha,
+		Manilhas:      slices.Clone(h.manilhas),
+		Pile:          make([]savedCard, len(h.pile)),
+		DeckWeights:   maps.Clone(h.deckWeights),
+		Points:        slices.Clone(h.points),
+		RoundWinners:  slices.Clone(h.roundWinners),
+		Round:         h.round,
+		WonTeam:       h.wonTeam,
+		DeckPosition:  h.deckPosition,
+		Dealer:        h.dealer,
+		Seats:         slices.Clone(h.seats),
+		CurrentPlayer: h.currentPlayer,
+		Stake:         h.stake,
+		RaisedBy:      h.raisedBy,
+		Phase:         h.phase,
+		MaoDeOnze:     h.maoDeOnze,
+		MaoDeFerro:    h.maoDeFerro,
+		Piezas:        maps.Clone(h.piezas),
+	}
+	for i, c := range h.pile {
+		saved.Pile[i] = savedCard{Card: c.card, Position: c.position, FaceDown: c.faceDown}
+	}
+	if h.call != nil {
+		saved.Call = &savedCall{Position: h.call.position, Stake: h.call.stake}
+	}
+	if e := h.envido; e != nil {
+		saved.Envido = &savedEnvido{
+			Calls:         slices.Clone(e.calls),
+			Position:      e.position,
+			Pending:       e.pending,
+			Announcements: make([]savedAnnouncement, len(e.announcements)),
+			Best:          e.best,
+			BestPoints:    e.bestPoints,
+			Done:          e.done,
+		}
+		for i, a := range e.announcements {
+			saved.Envido.Announcements[i] = savedAnnouncement{Player: g.position(a.Player), Points: a.Points, Conceded: a.Conceded}
+		}
+	}
+	if f := h.flor; f != nil {
+		saved.Flor = &savedFlor{Calls: slices.Clone(f.calls), Position: f.position, Pending: f.pending, Done: f.done}
+	}
+	return saved
+}
+
+func (g *Game) loadHand(saved savedHand, dealt bool) (*Hand, error) {
+	if !g.validHand(saved, dealt) {
+		return nil, ErrInvalidSave
+	}
+	h := &Hand{
+		deck:          saved.Deck,
+		manilha:       saved.Manilha,
+		manilhas:      saved.Manilhas,
+		pile:          make([]playedCard, len(saved.Pile)),
+		deckWeights:   saved.DeckWeights,
+		points:        saved.Points,
+		roundWinners:  saved.RoundWinners,
+		round:         saved.Round,
+		wonTeam:       saved.WonTeam,
+		deckPosition:  saved.DeckPosition,
+		dealer:        saved.Dealer,
+		seats:         saved.Seats,
+		currentPlayer: saved.CurrentPlayer,
+		stake:         saved.Stake,
+		raisedBy:      saved.RaisedBy,
+		phase:         saved.Phase,
+		maoDeOnze:     saved.MaoDeOnze,
+		maoDeFerro:    saved.MaoDeFerro,
+		piezas:        saved.Piezas,
+	}
+	for i, c := range saved.Pile {
+		h.pile[i] = playedCard{card: c.Card, position: c.Position, faceDown: c.FaceDown}
+	}
+	if saved.Call != nil {
+		h.call = &call{position: saved.Call.Position, stake: saved.Call.Stake}
+	}
+	if e := saved.Envido; e != nil {
+		h.envido = &envido{
+			calls:      e.Calls,
+			position:   e.Position,
+			pending:    e.Pending,
+			best:       e.Best,
+			bestPoints: e.BestPoints,
+			done:       e.Done,
+		}
+		for _, a := range e.Announcements {
+			if a.Player < 0 || a.Player >= len(g.players) {
+				return nil, ErrInvalidSave
+			}
+			h.envido.announcements = append(h.envido.announcements, EnvidoAnnouncement{Player: g.players[a.Player], Points: a.Points, Conceded: a.Conceded})
+		}
+	}
+	if f := saved.Flor; f != nil {
+		h.flor = &flor{calls: f.Calls, position: f.Position, pending: f.Pending, done: f.Done}
+	}
+	return h, nil
+}
+
+// validHand checks that every index and position of a saved hand is in range
+// and that a dealt hand has its seats, so the loaded game can't panic or end up
+// in a state it can't get out of
+func (g *Game) validHand(saved savedHand, dealt bool) bool {
+	validPosition := func(position int) bool { return position >= 0 && position < len(g.players) }
+	validTeam := func(team int) bool { return team >= -1 && team <= 1 }
+
+	if dealt && (len(saved.Seats) == 0 || !slices.Contains(saved.Seats, int(saved.CurrentPlayer))) {
+		return false
+	}
+	seats := slices.Clone(saved.Seats)
+	slices.Sort(seats)
+	if len(slices.Compact(seats)) != len(saved.Seats) {
+		return false
+	}
+
+	positions := append(slices.Clone(saved.Seats), saved.Dealer, int(saved.CurrentPlayer))
+	for _, c := range saved.Pile {
+		positions = append(positions, c.Position)
+	}
+	if saved.Call != nil {
+		positions = append(positions, saved.Call.Position)
+		if saved.Call.Stake < 0 || saved.Call.Stake >= len(g.rules.Stakes) {
+			return false
+		}
+	}
+	if e := saved.Envido; e != nil {
+		positions = append(positions, e.Position)
+		if e.Best != -1 {
+			positions = append(positions, e.Best)
+		}
+	}
+	if f := saved.Flor; f != nil {
+		positions = append(positions, f.Position)
+	}
+	for _, position := range positions {
+		if !validPosition(position) {
+			return false
+		}
+	}
+
+	if int(saved.DeckPosition) > len(saved.Deck) || saved.Round > 3 || len(saved.Points) != 3 || len(saved.RoundWinners) != 3 {
+		return false
+	}
+	for i := range 3 {
+		if !validTeam(saved.Points[i]) || (saved.RoundWinners[i] != -1 && !validPosition(saved.RoundWinners[i])) {
+			return false
+		}
+	}
+	if saved.Stake < 0 || saved.Stake >= len(g.rules.Stakes) {
+		return false
+	}
+	return validTeam(saved.RaisedBy) && validTeam(saved.MaoDeOnze) && validTeam(saved.WonTeam)
+}
+
+// marshalName returns the name a value is saved with. Enums are saved by name
+// so saved games don't change meaning if their values are added or reordered
+func marshalName[T comparable](names map[T]string, value T, err error) ([]byte, error) {
+	name, ok := names[value]
+	if !ok {
+		return nil, err
+	}
+	return []byte(name), nil
+}
+
+// unmarshalName sets the value saved with the name
+func unmarshalName[T comparable](names map[T]string, text []byte, value *T, err error) error {
+	for v, name := range names {
+		if name == string(text) {
+			*value = v
+			return nil
+		}
+	}
+	return err
+}
@@ -0,0 +1,222 @@
+package truco
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestSave(t *testing.T) {
+	g := startedGame(t)
+	playMoves(t, g, 25)
+	if _, err := g.Truco(g.CurrentPlayer()); err != nil {
+		t.Fatal("failed to call truco: " + err.Error())
+	}
+
+	data, err := json.Marshal(g)
+	if err != nil {
+		t.Fatal("failed to save game: " + err.Error())
+	}
+	var loaded Game
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatal("failed to load game: " + err.Error())
+	}
+	if !loaded.Running() || !reflect.DeepEqual(loaded.score, g.score) {
+		t.Errorf("loaded game should be running with score %v, instead got: %v", g.score, loaded.score)
+	}
+	if !reflect.DeepEqual(loaded.hands, g.hands) {
+		t.Error("loaded game should have the same hands")
+	}
+	if !reflect.DeepEqual(loaded.players, g.players) {
+		t.Error("loaded game should have the same players and cards")
+	}
+	if !reflect.DeepEqual(loaded.Log(), g.Log()) {
+		t.Error("loaded game should have the same log")
+	}
+	// the pending truco call is answered in the loaded game
+	if _, err := loaded.Accept(loaded.players[loaded.hand().call.position^1]); err != nil {
+		t.Fatal("failed to accept truco in the loaded game: " + err.Error())
+	}
+}
+
+func TestSaveVersions(t *testing.T) {
+	g := startedGame(t)
+	playMoves(t, g, 10)
+
+	// version 1 saves have only the log, with the actions saved as numbers
+	data, err := json.Marshal(savedGame{Version: 1, Log: g.Log()})
+	if err != nil {
+		t.Fatal("failed to save game: " + err.Error())
+	}
+	data = oldSave(t, data, map[string]string{
+		`"action":"play"`: `"action":0`, `"action":"truco"`: `"action":1`, `"action":"accept"`: `"action":2`,
+		`"action":"playPosition"`: `"action":5`, `"action":"acceptMaoDeOnze"`: `"action":7`,
+		`"manilha":"vira"`: `"manilha":1`, `"tieRule":"nobodyScores"`: `"tieRule":2`,
+		`"envido":"envido"`: `"envido":0`, `"flor":"flor"`: `"flor":0`,
+	})
+	var loaded Game
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatal("failed to load version 1 game: " + err.Error())
+	}
+	if !reflect.DeepEqual(loaded.hands, g.hands) || !reflect.DeepEqual(loaded.score, g.score) ||
+		!reflect.DeepEqual(loaded.Log(), g.Log()) {
+		t.Error("version 1 game should be rebuilt from its log")
+	}
+	// the first action gets an unknown number
+	unknown := bytes.Replace(data, []byte(`"action":`), []byte(`"action":99,"was":`), 1)
+	if err := json.Unmarshal(unknown, &loaded); err != ErrInvalidSave {
+		t.Errorf("expected error ErrInvalidSave, instead got: %v", err)
+	}
+
+	// version 2 saves have the state, with everything but the actions saved as
+	// numbers
+	if data, err = json.Marshal(g); err != nil {
+		t.Fatal("failed to save game: " + err.Error())
+	}
+	data = oldSave(t, data, map[string]string{
+		`"version":3`: `"version":2`, `"manilha":"vira"`: `"manilha":1`, `"tieRule":"nobodyScores"`: `"tieRule":2`,
+		`"envido":"envido"`: `"envido":0`, `"flor":"flor"`: `"flor":0`,
+		`"phase":"playing"`: `"phase":0`, `"phase":"maoDeOnze"`: `"phase":1`,
+	})
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatal("failed to load version 2 game: " + err.Error())
+	}
+	if !reflect.DeepEqual(loaded.hands, g.hands) || !reflect.DeepEqual(loaded.Log(), g.Log()) {
+		t.Error("version 2 game should load with the same hands and log")
+	}
+
+	for _, version := range []int{0, saveVersion + 1} {
+		data, err := json.Marshal(savedGame{Version: version, Log: g.Log()})
+		if err != nil {
+			t.Fatal("failed to save game: " + err.Error())
+		}
+		if err := json.Unmarshal(data, &loaded); err != ErrUnknownSaveVersion {
+			t.Errorf("version %d: expected error ErrUnknownSaveVersion, instead got: %v", version, err)
+		}
+	}
+	data, err = json.Marshal(savedGame{Version: saveVersion, Log: g.Log()})
+	if err != nil {
+		t.Fatal("failed to save game: " + err.Error())
+	}
+	if err := json.Unmarshal(data, &loaded); err != ErrInvalidSave {
+		t.Errorf("expected error ErrInvalidSave, instead got: %v", err)
+	}
+}
+
+// oldSave replaces names in a save with what an older version saved instead,
+// every name of the replaced fields has to be replaced
+func oldSave(t *testing.T, data []byte, replacements map[string]string) []byte {
+	t.Helper()
+	for name, old := range replacements {
+		data = bytes.ReplaceAll(data, []byte(name), []byte(old))
+	}
+	// card IDs start with a digit or an upper case letter, names with a lower
+	// case letter
+	for name := range replacements {
+		field, _, _ := strings.Cut(name, ":")
+		if found := regexp.MustCompile(field + `:"[a-z]`).Find(data); found != nil {
+			t.Fatalf("expected every name to be replaced, instead found: %s", found)
+		}
+	}
+	return data
+}
+
+func TestSaveInvalidHand(t *testing.T) {
+	g := startedGame(t)
+	playMoves(t, g, 3)
+
+	for name, change := range map[string]func(h *savedHand){
+		"current player": func(h *savedHand) { h.CurrentPlayer = 7 },
+		"round":          func(h *savedHand) { h.Round = 9 },
+		"stake":          func(h *savedHand) { h.Stake = 9 },
+		"mão de onze":    func(h *savedHand) { h.MaoDeOnze = 2 },
+		"pile":           func(h *savedHand) { h.Pile[0].Position = -1 },
+		"call":           func(h *savedHand) { h.Call = &savedCall{Position: 0, Stake: 9} },
+		"no seats":       func(h *savedHand) { h.Seats = []int{} },
+		"repeated seats": func(h *savedHand) { h.Seats = []int{0, 0} },
+		"seated player":  func(h *savedHand) { h.Seats, h.CurrentPlayer = []int{0}, 1 },
+	} {
+		data, err := json.Marshal(g)
+		if err != nil {
+			t.Fatal("failed to save game: " + err.Error())
+		}
+		var saved savedGame
+		if err := json.Unmarshal(data, &saved); err != nil {
+			t.Fatal("failed to read saved game: " + err.Error())
+		}
+		change(&saved.State.Hands[len(saved.State.Hands)-1])
+		if data, err = json.Marshal(saved); err != nil {
+			t.Fatal("failed to save game: " + err.Error())
+		}
+		var loaded Game
+		if err := json.Unmarshal(data, &loaded); err != ErrInvalidSave {
+			t.Errorf("%s: expected error ErrInvalidSave, instead got: %v", name, err)
+		}
+	}
+}
+
+func TestSaveNames(t *testing.T) {
+	move := Move{Action: ActionCut, Envido: RealEnvido, Flor: ContraFlor, Choices: []CutChoice{CutFromBottom}}
+	rules := RuleSet{Manilha: ManilhaMuestra, TieRule: TieNobodyScores, EnvidoCalls: []EnvidoCall{FaltaEnvido}}
+	data, err := json.Marshal(map[string]any{"move": move, "rules": rules, "phase": phaseMaoDeOnze})
+	if err != nil {
+		t.Fatal("failed to save move: " + err.Error())
+	}
+	var saved struct {
+		Move struct {
+			Action  string   `json:"action"`
+			Envido  string   `json:"envido"`
+			Flor    string   `json:"flor"`
+			Choices []string `json:"choices"`
+		} `json:"move"`
+		Rules struct {
+			Manilha     string   `json:"manilha"`
+			TieRule     string   `json:"tieRule"`
+			EnvidoCalls []string `json:"envidoCalls"`
+		} `json:"rules"`
+		Phase string `json:"phase"`
+	}
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatal("failed to read saved move: " + err.Error())
+	}
+	if saved.Move.Action != "cut" || saved.Move.Envido != "realEnvido" || saved.Move.Flor != "contraFlor" ||
+		!reflect.DeepEqual(saved.Move.Choices, []string{"fromBottom"}) {
+		t.Errorf("expected the move to be saved by name, instead got: %s", data)
+	}
+	if saved.Rules.Manilha != "muestra" || saved.Rules.TieRule != "nobodyScores" ||
+		!reflect.DeepEqual(saved.Rules.EnvidoCalls, []string{"faltaEnvido"}) || saved.Phase != "maoDeOnze" {
+		t.Errorf("expected the rules and phase to be saved by name, instead got: %s", data)
+	}
+
+	var loaded struct {
+		Move  Move    `json:"move"`
+		Rules RuleSet `json:"rules"`
+		Phase phase   `json:"phase"`
+	}
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatal("failed to load move: " + err.Error())
+	}
+	if !equalMoves(loaded.Move, move) || loaded.Rules.Manilha != ManilhaMuestra || loaded.Rules.TieRule != TieNobodyScores ||
+		!reflect.DeepEqual(loaded.Rules.EnvidoCalls, rules.EnvidoCalls) || loaded.Phase != phaseMaoDeOnze {
+		t.Errorf("expected to load the saved values, instead got: %v", loaded)
+	}
+
+	if err := json.Unmarshal([]byte(`{"action":"shuffle"}`), &move); err != ErrInvalidAction {
+		t.Errorf("expected error ErrInvalidAction, instead got: %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{"envido":"doubleEnvido"}`), &move); err != ErrUnknownName {
+		t.Errorf("expected error ErrUnknownName, instead got: %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{"tieRule":1}`), &rules); err == nil {
+		t.Error("expected an error loading a tie rule saved as a number")
+	}
+	if _, err := json.Marshal(Move{Action: Action(99)}); err == nil {
+		t.Error("expected an error saving an unknown action")
+	}
+	if _, err := json.Marshal(RuleSet{TieRule: TieRule(9)}); err == nil {
+		t.Error("expected an error saving an unknown tie rule")
+	}
+}
@@ -0,0 +1,91 @@
+package truco
+
+// TieRule decides who wins a hand when rounds end in a draw ("cangar")
+type TieRule int
+
+const (
+	// TieFirstRound gives the hand to the team that won the first round when a
+	// later round is tied. If the first round is tied, the next round won
+	// decides the hand. If every round is tied, the team of the hand leader wins
+	TieFirstRound TieRule = iota
+	// TieNextRound gives a tied round to the team that wins the round after it.
+	// A tied last round goes to the team that won the first round. If every
+	// round is tied, the team of the hand leader wins
+	TieNextRound
+	// TieNobodyScores works like TieFirstRound, but if every round is tied
+	// nobody scores the hand
+	TieNobodyScores
+)
+
+// tieRuleNames are the names of the tie rules in saved games
+var tieRuleNames = map[TieRule]string{
+	TieFirstRound:   "firstRound",
+	TieNextRound:    "nextRound",
+	TieNobodyScores: "nobodyScores",
+}
+
+func (r TieRule) MarshalText() ([]byte, error) {
+	return marshalName(tieRuleNames, r, ErrUnknownName)
+}
+
+func (r *TieRule) UnmarshalText(text []byte) error {
+	return unmarshalName(tieRuleNames, text, r, ErrUnknownName)
+}
+
+// handWinner returns the team that won the hand given the winners of the rounds
+// played so far, -1 being a draw, and if the hand is over
+func (r TieRule) handWinner(points []int, leaderTeam int) (int, bool) {
+	wins := make([]int, 2)
+	firstWinner := -1
+	tied := false
+	for _, point := range points {
+		if point == -1 {
+			tied = true
+			continue
+		}
+		wins[point] += 1
+		if firstWinner == -1 {
+			firstWinner = point
+		}
+	}
+	for team, w := range wins {
+		if w == 2 {
+			return team, true
+		}
+	}
+
+	if r == TieNextRound {
+		// each tied round counts for the team that won the next decided round
+		for i, point := range points {
+			if point != -1 {
+				continue
+			}
+			winner := -1
+			for _, next := range points[i+1:] {
+				if next != -1 {
+					winner = next
+					break
+				}
+			}
+			if winner == -1 && len(points) == 3 {
+				winner = firstWinner
+			}
+			if winner != -1 {
+				wins[winner] += 1
+				if wins[winner] == 2 {
+					return winner, true
+				}
+			}
+		}
+	} else if tied && firstWinner != -1 {
+		return firstWinner, true
+	}
+
+	if len(points) < 3 {
+		return -1, false
+	}
+	if firstWinner == -1 && r != TieNobodyScores {
+		return leaderTeam, true
+	}
+	return firstWinner, true
+}
@@ -0,0 +1,120 @@
+package truco
+
+import "testing"
+
+type tieCase struct {
+	points []int
+	winner int
+	over   bool
+}
+
+func TestTieFirstRound(t *testing.T) {
+	checkTieRule(t, TieFirstRound, []tieCase{
+		{[]int{0}, -1, false},
+		{[]int{0, 0}, 0, true},
+		{[]int{0, 1}, -1, false},
+		{[]int{0, -1}, 0, true},
+		{[]int{-1, 1}, 1, true},
+		{[]int{-1, -1}, -1, false},
+		{[]int{-1, -1, 0}, 0, true},
+		{[]int{0, 1, -1}, 0, true},
+		{[]int{1, 0, 0}, 0, true},
+		// every round tied, the leader's team wins
+		{[]int{-1, -1, -1}, 1, true},
+	})
+}
+
+func TestTieNextRound(t *testing.T) {
+	checkTieRule(t, TieNextRound, []tieCase{
+		{[]int{0, 0}, 0, true},
+		// the tied second round goes to whoever wins the third
+		{[]int{0, -1}, -1, false},
+		{[]int{0, -1, 1}, 1, true},
+		{[]int{0, -1, 0}, 0, true},
+		{[]int{-1, 1}, 1, true},
+		{[]int{-1, -1}, -1, false},
+		{[]int{-1, -1, 0}, 0, true},
+		// there is no round after the last one, the first round decides
+		{[]int{0, 1, -1}, 0, true},
+		{[]int{0, -1, -1}, 0, true},
+		{[]int{-1, -1, -1}, 1, true},
+	})
+}
+
+func TestTieNobodyScores(t *testing.T) {
+	checkTieRule(t, TieNobodyScores, []tieCase{
+		{[]int{0, -1}, 0, true},
+		{[]int{-1, 1}, 1, true},
+		{[]int{0, 1, -1}, 0, true},
+		{[]int{-1, -1, -1}, -1, true},
+	})
+}
+
+func TestTiedFirstRound(t *testing.T) {
+	g := startedGame(t)
+	p1 := g.players[0]
+	p2 := g.players[1]
+
+	if _, err := g.Play(p1, ThreeDiamonds); err != nil {
+		t.Error("failed to play card: " + err.Error())
+	}
+	if _, err := g.Play(p2, ThreeClubs); err != nil {
+		t.Error("failed to play card: " + err.Error())
+	}
+	if g.LastPoint() != nil || g.LastPointTeam() != -1 {
+		t.Error("first round should be a draw")
+	}
+	if g.CurrentPlayer() != p1 {
+		t.Error("player 1 played the tied card first and should start the next round")
+	}
+	if _, err := g.Play(p1, QueenSpades); err != nil {
+		t.Error("failed to play card: " + err.Error())
+	}
+	if _, err := g.Play(p2, SevenClubs); err != nil {
+		t.Error("failed to play card: " + err.Error())
+	}
+	if g.Winner() != p1 {
+		t.Error("player 1 should have won the hand with the second round")
+	}
+	if g.Scores()[0] != 1 {
+		t.Errorf("expected player 1 to score 1 point, instead got: %d", g.Scores()[0])
+	}
+}
+
+// TestAllRoundsTied checks that nobody scores a hand with every round tied
+// under the default rules
+func TestAllRoundsTied(t *testing.T) {
+	g := startedGame(t)
+	p1 := g.players[0]
+	p2 := g.players[1]
+	// the manilhas are the fours
+	p1.cards = []Card{FiveHearts, SixSpades, KingClubs}
+	p2.cards = []Card{FiveClubs, SixHearts, KingSpades}
+
+	for i := range 3 {
+		if _, err := g.Play(p1, p1.cards[0]); err != nil {
+			t.Fatal("failed to play card: " + err.Error())
+		}
+		if _, err := g.Play(p2, p2.cards[0]); err != nil {
+			t.Fatal("failed to play card: " + err.Error())
+		}
+		if i < 2 && g.LastPointTeam() != -1 {
+			t.Fatalf("round %d should be a draw", i)
+		}
+	}
+	if len(g.hands) != 2 {
+		t.Fatal("hand should be over after 3 tied rounds")
+	}
+	if g.WinnerTeam() != -1 || g.Scores()[0] != 0 || g.Scores()[1] != 0 {
+		t.Errorf("nobody should score the hand, instead got winner %d and score %v", g.WinnerTeam(), g.Scores())
+	}
+}
+
+func checkTieRule(t *testing.T, rule TieRule, cases []tieCase) {
+	for _, c := range cases {
+		winner, over := rule.handWinner(c.points, 1)
+		if winner != c.winner || over != c.over {
+			t.Errorf("rounds %v: expected (%d, %t), instead got: (%d, %t)", c.points, c.winner, c.over, winner, over)
+		}
+	}
+}
@@ -2,25 +2,95 @@ package truco
 
 import (
 	"errors"
+	"maps"
+	"math/rand/v2"
+	"slices"
 
 	gonanoid "github.com/matoous/go-nanoid/v2"
 )
 
 var (
-	ErrGameFull              = errors.New("the game has reached the maximum amount of players")
-	ErrNameTooLong           = errors.New("player name has more than 100 characters")
-	ErrNameTooShort          = errors.New("player name has less than 2 characters")
-	ErrPlayerAlreadyInGame   = errors.New("player is already in the game")
-	ErrPlayerNotFound        = errors.New("player id not found")
-	ErrNotEnoughPlayers      = errors.New("not enough players to start the game")
-	ErrGameNotRunning        = errors.New("game is not running")
-	ErrNotPlayerTurn         = errors.New("not the player's turn")
-	ErrPlayerDoesNotHaveCard = errors.New("player does not have the card")
+	ErrGameFull                = errors.New("the game has reached the maximum amount of players")
+	ErrNameTooLong             = errors.New("player name has more than 100 characters")
+	ErrNameTooShort            = errors.New("player name has less than 2 characters")
+	ErrPlayerAlreadyInGame     = errors.New("player is already in the game")
+	ErrPlayerNotFound          = errors.New("player id not found")
+	ErrNotEnoughPlayers        = errors.New("not enough players to start the game")
+	ErrGameNotRunning          = errors.New("game is not running")
+	ErrNotPlayerTurn           = errors.New("not the player's turn")
+	ErrPlayerDoesNotHaveCard   = errors.New("player does not have the card")
+	ErrCallPending             = errors.New("a truco call is waiting for an answer")
+	ErrNoCallPending           = errors.New("there is no truco call to answer")
+	ErrOwnCall                 = errors.New("player cannot answer or raise their own call")
+	ErrCannotRaise             = errors.New("the hand value cannot be raised any further")
+	ErrMaoDeOnzePending        = errors.New("waiting for the mão de onze decision")
+	ErrNoMaoDeOnze             = errors.New("there is no mão de onze decision to make")
+	ErrNotMaoDeOnzeSide        = errors.New("player is not on the side playing the mão de onze")
+	ErrTrucoInMaoDeOnze        = errors.New("truco cannot be called during a mão de onze")
+	ErrTrucoInMaoDeFerro       = errors.New("truco cannot be called during a mão de ferro")
+	ErrHiddenCards             = errors.New("cards are hidden during a mão de ferro, play them by position")
+	ErrInvalidCardPosition     = errors.New("player does not have a card in this position")
+	ErrFaceDownFirstRound      = errors.New("cards can only be played face down after the first round")
+	ErrInvalidPlayerCount      = errors.New("a game can only have 2, 4 or 6 players")
+	ErrNotInHand               = errors.New("player is not playing this hand")
+	ErrGameRunning             = errors.New("game has already started")
+	ErrEnvidoNotAllowed        = errors.New("envido is not played with these rules")
+	ErrEnvidoClosed            = errors.New("envido can only be called in the first round before the player plays a card")
+	ErrInvalidEnvidoCall       = errors.New("envido call cannot follow the previous calls")
+	ErrEnvidoPending           = errors.New("the envido has to be settled first")
+	ErrNoEnvidoAnnouncement    = errors.New("there is no accepted envido to announce")
+	ErrEnvidoNotBetter         = errors.New("player's envido does not beat the best announced, they should concede")
+	ErrEnvidoFirstAnnouncement = errors.New("the first player to speak has to announce their envido")
+	ErrFlorNotAllowed          = errors.New("flor is not played in this game")
+	ErrNoFlor                  = errors.New("player does not have a flor")
+	ErrFlorClosed              = errors.New("flor can only be called in the first round before the player plays a card")
+	ErrInvalidFlorCall         = errors.New("flor call cannot follow the previous calls")
+	ErrFlorPending             = errors.New("the flor has to be settled first")
+	ErrInvalidTargetScore      = errors.New("target score must be above the starting scores")
+	ErrInvalidStartingScore    = errors.New("starting score must be below the target score")
+	ErrInvalidTeam             = errors.New("team must be 0 or 1")
+	ErrDeckTooSmall            = errors.New("the deck does not have enough cards for every player")
+	ErrCutPending              = errors.New("the deck has to be cut first")
+	ErrNoCut                   = errors.New("there is no deck to cut")
+	ErrNotCutter               = errors.New("player is not the one cutting the deck")
+	ErrInvalidCutPosition      = errors.New("the cut has to leave cards in both parts of the deck")
+	ErrBaterWithoutVira        = errors.New("the cut card can only be kept when a card is turned up")
 )
 
 // Actions
 type Action int
 
+const (
+	// ActionPlay plays a card from the player's hand
+	ActionPlay Action = iota
+	// ActionTruco calls truco, or raises the pending call to the next value
+	ActionTruco
+	// ActionAccept accepts the pending call
+	ActionAccept
+	// ActionRefuse refuses the pending call and gives up the hand
+	ActionRefuse
+	// ActionPlayFaceDown plays a card face down
+	ActionPlayFaceDown
+	// ActionPlayPosition plays the card at a position of the player's hand
+	ActionPlayPosition
+	// ActionFold gives up the hand
+	ActionFold
+	// ActionAcceptMaoDeOnze plays the mão de onze
+	ActionAcceptMaoDeOnze
+	// ActionRefuseMaoDeOnze gives up the mão de onze
+	ActionRefuseMaoDeOnze
+	// ActionCallEnvido calls or raises the envido
+	ActionCallEnvido
+	// ActionAnnounceEnvido shows the player's envido points
+	ActionAnnounceEnvido
+	// ActionConcedeEnvido concedes the envido ("son buenas")
+	ActionConcedeEnvido
+	// ActionCallFlor sings or raises the flor
+	ActionCallFlor
+	// ActionCut cuts the deck
+	ActionCut
+)
+
 type Game struct {
 	// ID of the game
 	id string
@@ -36,47 +106,146 @@ type Game struct {
 	running bool
 	// state of hands of rounds
 	hands []*Hand
+	// points scored by each team, players in even positions are team 0
+	score []int
+	// rules the game is played with
+	rules RuleSet
+	// in 6 player games, every other hand is played head-to-head by the pés
+	peRotation bool
+	// position of the dealer of the first hand, -1 = last position
+	dealer int
+	// the deck is cut before each hand is dealt
+	cut bool
+	// called with the winner team when the game is over
+	onGameOver func(team int) error
+	// called with every event of the game
+	subscribers []func(event Event)
+	// events of the action being run
+	events []Event
+	// score of each team before the first hand
+	startingScore []int
+	// moves made so far, in order
+	moves []Move
 }
 
 type Hand struct {
 	// deck of cards
 	deck []Card
-	// manilha card
+	// card turned up to choose the manilhas (vira), empty when they are fixed
 	manilha Card
+	// cards that beat the regular order in the hand, from the lowest to the highest
+	manilhas []Card
 	// played cards in order
-	pile []Card
+	pile []playedCard
 	// weight of each card
 	deckWeights map[Card]int
-	// who won the round 0 = draw, 1 = player 1, 2 = player 2
+	// team that won each round, -1 = draw
 	points []int
+	// position of the player who won each round, -1 = draw
+	roundWinners []int
 	// current round
 	round uint
-	// -1 = draw, 0 = player 1, 1 = player 2
-	wonPosition int
+	// team that won the hand, -1 = draw
+	wonTeam int
 	// next card to pull from the deck
 	deckPosition uint
+	// position of the player who dealt the hand
+	dealer int
+	// positions of the players dealt into the hand, in turn order starting
+	// with the player after the dealer, who leads the first round
+	seats []int
 	// player who will play the next card
 	currentPlayer uint
+	// accepted betting level, index of Game.stakes
+	stake int
+	// team that made the last accepted call, -1 = none
+	raisedBy int
+	// truco call waiting for an answer
+	call *call
+	// what the hand is waiting for before cards can be played
+	phase phase
+	// team deciding the mão de onze, -1 = not a mão de onze
+	maoDeOnze int
+	// both sides have eleven points, cards are dealt hidden and there are no calls
+	maoDeFerro bool
+	// envido bet, nil if it wasn't called
+	envido *envido
+	// flor bet, nil if it wasn't called
+	flor *flor
+	// envido value of the piezas of the muestra suit, nil if there is no muestra
+	piezas map[Card]int
+}
+
+type playedCard struct {
+	card Card
+	// position of the player who played the card
+	position int
+	// played face down (encoberta), it never wins and its value stays hidden
+	faceDown bool
+}
+
+type phase int
+
+const (
+	// cards can be played
+	phasePlaying phase = iota
+	// the side with eleven points has to decide if the hand will be played
+	phaseMaoDeOnze
+	// the deck has to be cut before the cards are dealt
+	phaseCut
+)
+
+// phaseNames are the names of the hand phases in saved games
+var phaseNames = map[phase]string{
+	phasePlaying:   "playing",
+	phaseMaoDeOnze: "maoDeOnze",
+	phaseCut:       "cut",
+}
+
+func (p phase) MarshalText() ([]byte, error) {
+	return marshalName(phaseNames, p, ErrUnknownName)
+}
+
+func (p *phase) UnmarshalText(text []byte) error {
+	return unmarshalName(phaseNames, text, p, ErrUnknownName)
 }
 
 type Player struct {
 	id    string
 	name  string
 	cards []Card
+	// cards dealt in the current hand, including the ones already played
+	dealt []Card
 }
 
-func NewGame() (*Game, error) {
+// NewGame creates a 2 player game with the Paulista rules, the options change
+// the rules and settings of the game
+func NewGame(opts ...Option) (*Game, error) {
 	id, err := gonanoid.New()
 	if err != nil {
 		return nil, err
 	}
 	game := Game{
-		id:         id,
-		maxPlayers: 2,
-		players:    make([]*Player, 0),
-		seed1:      0,
-		seed2:      0,
-		hands:      []*Hand{newHand()},
+		id:            id,
+		maxPlayers:    2,
+		players:       make([]*Player, 0),
+		seed1:         0,
+		seed2:         0,
+		hands:         []*Hand{newHand()},
+		score:         make([]int, 2),
+		startingScore: make([]int, 2),
+		rules:         Paulista.Rules(),
+		dealer:        -1,
+	}
+	for stage := stageRules; stage <= stageScores; stage++ {
+		for _, opt := range opts {
+			if opt.stage != stage {
+				continue
+			}
+			if err := opt.apply(&game); err != nil {
+				return nil, err
+			}
+		}
 	}
 	return &game, nil
 }
@@ -84,12 +253,17 @@ func NewGame() (*Game, error) {
 func newHand() *Hand {
 	return &Hand{
 		deck:          nil,
-		pile:          make([]Card, 0),
+		pile:          make([]playedCard, 0),
 		deckWeights:   DefaultDeckWeights(),
 		currentPlayer: 0,
 		deckPosition:  0,
 		round:         0,
 		points:        make([]int, 3),
+		roundWinners:  make([]int, 3),
+		stake:         0,
+		raisedBy:      -1,
+		phase:         phasePlaying,
+		maoDeOnze:     -1,
 	}
 }
 
@@ -112,9 +286,106 @@ func NewPlayer(name string) (*Player, error) {
 	return &player, nil
 }
 
-func (g *Game) Seed(seed1, seed2 uint64) {
+// Seed sets the seeds of the random number generator that shuffles the deck,
+// they can't change once the game started or the log would not replay it
+func (g *Game) Seed(seed1, seed2 uint64) error {
+	if g.running {
+		return ErrGameRunning
+	}
 	g.seed1 = seed1
 	g.seed2 = seed2
+	return nil
+}
+
+// SetMaxPlayers changes how many players the game has, either 2, 4 or 6. Teams
+// alternate around the table, with 4 players they are made of the players in
+// positions {0, 2} and {1, 3}, with 6 players {0, 2, 4} and {1, 3, 5}
+func (g *Game) SetMaxPlayers(maxPlayers int) error {
+	if g.running {
+		return ErrGameRunning
+	}
+	if maxPlayers != 2 && maxPlayers != 4 && maxPlayers != 6 {
+		return ErrInvalidPlayerCount
+	}
+	if len(g.players) > maxPlayers {
+		return ErrGameFull
+	}
+	g.maxPlayers = maxPlayers
+	return nil
+}
+
+// SetPeRotation enables the regional rule for 6 player games where every other
+// hand is played head-to-head by the pés, one player of each team. The first
+// pés are the players in positions 0 and 1, after each head-to-head hand the
+// next player of each team becomes the pé
+func (g *Game) SetPeRotation(enabled bool) error {
+	if g.running {
+		return ErrGameRunning
+	}
+	g.peRotation = enabled
+	return nil
+}
+
+// SetDealer chooses who deals the first hand, by default it is the player in
+// the last position so the first player leads the first hand
+func (g *Game) SetDealer(player *Player) error {
+	if g.running {
+		return ErrGameRunning
+	}
+	position := g.position(player)
+	if position == -1 {
+		return ErrPlayerNotFound
+	}
+	g.dealer = position
+	return nil
+}
+
+// SetTieRule chooses how tied rounds decide who wins the hand
+func (g *Game) SetTieRule(rule TieRule) error {
+	if g.running {
+		return ErrGameRunning
+	}
+	g.rules.TieRule = rule
+	return nil
+}
+
+// SetTargetScore changes how many points a team needs to win the game, the
+// mão de onze happens one hand away from it
+func (g *Game) SetTargetScore(target int) error {
+	if g.running {
+		return ErrGameRunning
+	}
+	for _, score := range g.score {
+		if target <= score {
+			return ErrInvalidTargetScore
+		}
+	}
+	g.rules.TargetScore = target
+	return nil
+}
+
+// SetStartingScore gives the team a handicap, a negative score or a head start
+// below the target score
+func (g *Game) SetStartingScore(team int, score int) error {
+	if g.running {
+		return ErrGameRunning
+	}
+	if team != 0 && team != 1 {
+		return ErrInvalidTeam
+	}
+	if score >= g.rules.TargetScore {
+		return ErrInvalidStartingScore
+	}
+	g.score[team] = score
+	g.startingScore[team] = score
+	return nil
+}
+
+// SetVariant replaces the rules of the game with the rules of a regional
+// variant, which changes the card weights, the manilhas and the value of the
+// hands
+func (g *Game) SetVariant(variant Variant) error {
+	return g.SetRules(variant.Rules())
 }
 
 func (g *Game) AddPlayer(player *Player) error {
@@ -148,15 +419,21 @@ func (g *Game) RemovePlayer(player *Player) error {
 	return nil
 }
 
-func (g *Game) Start() error {
+func (g *Game) Start() ([]Event, error) {
 	if len(g.players) != g.maxPlayers {
-		return ErrNotEnoughPlayers
+		return nil, ErrNotEnoughPlayers
+	}
+	// three cards for each player and one to turn up
+	if len(g.rules.Deck.Cards()) < 3*len(g.players)+1 {
+		return nil, ErrDeckTooSmall
 	}
 
-	g.startHand()
+	if g.seed1 == 0 || g.seed2 == 0 {
+		// keep the random seeds so the game can be replayed, they are never zero
+		g.seed1, g.seed2 = rand.Uint64()|1, rand.Uint64()|1
+	}
 	g.running = true
-
-	return nil
+	return g.flush(g.startHand())
 }
 
 func (g *Game) hand() *Hand {
@@ -165,47 +442,121 @@ func (g *Game) hand() *Hand {
 
 func (g *Game) startHand() error {
 	currentHand := len(g.hands) - 1
-	if g.seed2 != 0 {
-		g.seed2 += uint64(currentHand)
+	g.hand().dealer = g.handDealer()
+	g.hand().seats = g.handSeats()
+	g.hand().currentPlayer = uint(g.hand().seats[0])
+	// every hand has its own seed, derived from the game seeds
+	g.hand().deck = shuffleDeck(g.rules.Deck.Cards(), g.seed1, g.seed2+uint64(currentHand))
+	g.hand().deckWeights = maps.Clone(g.rules.DeckWeights)
+	if g.cut {
+		g.hand().phase = phaseCut
+		g.clearCards()
+		return nil
 	}
-	g.hand().deck = ShuffledDeck(g.seed1, g.seed2)
-	if err := g.hand().setManilha(); err != nil {
-		return err
+	return g.deal()
+}
+
+// deal turns up the card that chooses the manilhas and deals the cards of the
+// hand
+func (g *Game) deal() error {
+	switch g.rules.Manilha {
+	case ManilhaFixed:
+		g.hand().setFixedManilhas(g.rules.Manilhas)
+	case ManilhaVira:
+		if err := g.hand().setManilha(g.rules.Deck, g.rules.ManilhaSuits); err != nil {
+			return err
+		}
+	case ManilhaMuestra:
+		g.hand().setMuestra()
 	}
 	g.drawCards()
+	g.checkMaoDeOnze()
+	g.emit(HandDealt{Hand: len(g.hands) - 1, Dealer: g.players[g.hand().dealer], Manilha: g.hand().manilha})
 	return nil
 }
 
-func (h *Hand) setManilha() error {
+// handDealer returns the position of the dealer of the current hand, the deal
+// moves one seat each hand
+func (g *Game) handDealer() int {
+	if len(g.hands) == 1 {
+		if g.dealer == -1 {
+			return len(g.players) - 1
+		}
+		return g.dealer
+	}
+	previousHand := g.hands[len(g.hands)-2]
+	return (previousHand.dealer + 1) % len(g.players)
+}
+
+// handSeats returns the positions of the players dealt into the current hand,
+// starting with the player after the dealer
+func (g *Game) handSeats() []int {
+	currentHand := len(g.hands) - 1
+	// pé a pé, the pés of each team face each other
+	headToHead := g.peRotation && len(g.players) == 6 && currentHand%2 == 1
+	pe := (currentHand / 2) % 3 * 2
+
+	seats := make([]int, 0, len(g.players))
+	for i := 1; i <= len(g.players); i++ {
+		seat := (g.hand().dealer + i) % len(g.players)
+		if headToHead && seat != pe && seat != pe+1 {
+			continue
+		}
+		seats = append(seats, seat)
+	}
+	return seats
+}
+
+func (h *Hand) setManilha(deck Deck, suits []string) error {
 	h.manilha = Card(h.deck[0])
 	h.deckPosition += 1
 
 	cardID := string(h.manilha[1])
-	manilhaID, err := nextCardID(cardID)
+	manilhaID, err := deck.nextRank(cardID)
 	if err != nil {
 		return err
 	}
 
-	spades := Card(Spades + manilhaID)
-	hearts := Card(Hearts + manilhaID)
-	diamonds := Card(Diamonds + manilhaID)
-	clubs := Card(Clubs + manilhaID)
-
 	// add weight to the cards based on their suit
-	h.deckWeights[clubs] += 10
-	h.deckWeights[diamonds] += 11
-	h.deckWeights[hearts] += 12
-	h.deckWeights[spades] += 13
+	for i, suit := range suits {
+		h.deckWeights[Card(suit+manilhaID)] += 10 + i
+		h.manilhas = append(h.manilhas, Card(suit+manilhaID))
+	}
 
 	return nil
 }
 
-func (g *Game) drawCards() {
+// setFixedManilhas makes the manilhas beat every other card, from the lowest
+// to the highest
+func (h *Hand) setFixedManilhas(manilhas []Card) {
+	top := 0
+	for c, weight := range h.deckWeights {
+		if !slices.Contains(manilhas, c) {
+			top = max(top, weight)
+		}
+	}
+	for i, c := range manilhas {
+		h.deckWeights[c] = top + 1 + i
+	}
+	h.manilhas = slices.Clone(manilhas)
+}
+
+func (g *Game) clearCards() {
 	for _, player := range g.players {
+		player.cards = make([]Card, 0, 3)
+		player.dealt = nil
+	}
+}
+
+func (g *Game) drawCards() {
+	g.clearCards()
+	for _, position := range g.hand().seats {
+		player := g.players[position]
 		for i := 0; i < 3; i++ {
 			player.cards = append(player.cards, g.hand().deck[g.hand().deckPosition])
 			g.hand().deckPosition += 1
 		}
+		player.dealt = append([]Card(nil), player.cards...)
 	}
 }
 
@@ -218,83 +569,160 @@ func (p *Player) hasCard(card Card) bool {
 	return false
 }
 
-func (g *Game) Play(player *Player, card Card) error {
+func (g *Game) Play(player *Player, card Card) ([]Event, error) {
+	if err := g.checkPlay(player); err != nil {
+		return nil, err
+	}
+	if g.hand().maoDeFerro {
+		return nil, ErrHiddenCards
+	}
+	if !player.hasCard(card) {
+		return nil, ErrPlayerDoesNotHaveCard
+	}
+	move := Move{Action: ActionPlay, Player: g.position(player), Card: card}
+	return g.record(move, g.play(player, card, false))
+}
+
+// PlayFaceDown plays the card face down (encoberta), it can't win the round
+// and the other players don't get to see it. Only allowed after the first round
+func (g *Game) PlayFaceDown(player *Player, card Card) ([]Event, error) {
+	if err := g.checkPlay(player); err != nil {
+		return nil, err
+	}
+	if g.hand().maoDeFerro {
+		return nil, ErrHiddenCards
+	}
+	if g.hand().round == 0 {
+		return nil, ErrFaceDownFirstRound
+	}
+	if !player.hasCard(card) {
+		return nil, ErrPlayerDoesNotHaveCard
+	}
+	move := Move{Action: ActionPlayFaceDown, Player: g.position(player), Card: card}
+	return g.record(move, g.play(player, card, true))
+}
+
+// PlayPosition plays the card at the given position of the player's hand, it
+// is the only way to play during a mão de ferro, when players can't see their cards
+func (g *Game) PlayPosition(player *Player, position int) ([]Event, error) {
+	if err := g.checkPlay(player); err != nil {
+		return nil, err
+	}
+	if position < 0 || position >= len(player.cards) {
+		return nil, ErrInvalidCardPosition
+	}
+	move := Move{Action: ActionPlayPosition, Player: g.position(player), Position: position}
+	return g.record(move, g.play(player, player.cards[position], false))
+}
+
+func (g *Game) checkPlay(player *Player) error {
 	if !g.running {
 		return ErrGameNotRunning
 	}
 	if player.id != g.CurrentPlayer().id {
 		return ErrNotPlayerTurn
 	}
-	if !player.hasCard(card) {
-		return ErrPlayerDoesNotHaveCard
+	if g.hand().phase == phaseCut {
+		return ErrCutPending
+	}
+	if g.hand().phase == phaseMaoDeOnze {
+		return ErrMaoDeOnzePending
+	}
+	if g.hand().florOpen() {
+		return ErrFlorPending
 	}
+	if g.hand().envidoOpen() {
+		return ErrEnvidoPending
+	}
+	if g.hand().call != nil {
+		return ErrCallPending
+	}
+	return nil
+}
+
+func (g *Game) play(player *Player, card Card, faceDown bool) error {
 	// play the card
-	g.hand().playCard(player, card)
-
-	// only check who won the round on even number of cards
-	if len(g.hand().pile) != 0 && len(g.hand().pile)%2 == 0 {
-		// if the player wins the round, they start the next round
-		// compare the current card with the previous played card
-		compare := g.hand().compareCards(card, g.hand().pile[len(g.hand().pile)-2])
-		switch compare {
-		// case 1 means the current card is greater than the previous card
-		case 1:
-			g.hand().points[g.hand().round] = int(g.hand().currentPlayer)
-			// case 2 means the current card is less than the previous card
-		case 2:
-			g.hand().currentPlayer = g.hand().currentPlayer ^ 1
-			g.hand().points[g.hand().round] = int(g.hand().currentPlayer)
-			// case 0 means the current card is equal to the previous card
-		case 0:
-			g.hand().points[g.hand().round] = -1
-			g.hand().currentPlayer = uint(g.hand().points[0])
+	played := playedCard{card: card, position: g.position(player), faceDown: faceDown}
+	g.hand().playCard(player, played)
+	if faceDown {
+		card = CardBack
+	}
+	g.emit(CardPlayed{Player: player, Card: card, FaceDown: faceDown})
+
+	h := g.hand()
+	// only check who won the round after every player played a card
+	if len(h.pile)%len(h.seats) == 0 {
+		// the highest card wins the round, if both teams have it the round is a draw
+		round := h.pile[len(h.pile)-len(h.seats):]
+		best := round[0]
+		winner := g.team(best.position)
+		for _, c := range round[1:] {
+			switch h.compareCards(c, best) {
+			case 1:
+				best = c
+				winner = g.team(c.position)
+			case 0:
+				if g.team(c.position) != winner {
+					winner = -1
+				}
+			}
+		}
+		h.points[h.round] = winner
+		h.roundWinners[h.round] = -1
+		if winner != -1 {
+			h.roundWinners[h.round] = best.position
+			g.emit(RoundWon{Round: int(h.round), Player: g.players[best.position], Team: winner})
+		} else {
+			g.emit(RoundTied{Round: int(h.round)})
 		}
+		// the player who played the highest card starts the next round, on a
+		// draw it is the first player who played the tied card
+		h.currentPlayer = uint(best.position)
 
-		g.hand().round += 1
+		h.round += 1
 	} else {
-		// toggle player between zero and one
-		g.hand().currentPlayer = g.hand().currentPlayer ^ 1
+		h.currentPlayer = uint(h.nextSeat(int(h.currentPlayer)))
 	}
 
 	// check if the hand is over
-	if g.hand().round == 3 {
-		// cehck who won the hand
-		playerOnePoints := 0
-		playerTwoPoints := 0
-		for _, point := range g.hand().points {
-			if point == 0 {
-				playerOnePoints += 1
-			} else if point == 1 {
-				playerTwoPoints += 1
-			}
-		}
-		if playerOnePoints > playerTwoPoints {
-			g.hand().wonPosition = 0
-		} else if playerTwoPoints > playerOnePoints {
-			g.hand().wonPosition = 1
-		} else {
-			g.hand().wonPosition = g.hand().points[0]
-		}
-		g.hands = append(g.hands, newHand())
-		if err := g.startHand(); err != nil {
+	if winner, over := g.rules.TieRule.handWinner(h.points[:h.round], g.team(h.seats[0])); over {
+		if err := g.endHand(winner); err != nil {
 			return err
 		}
 	}
 
-	playerOneHands := 0
-	playerTwoHands := 0
-	for _, hand := range g.hands {
-		if hand.wonPosition == 0 {
-			playerOneHands += 1
-		} else if hand.wonPosition == 1 {
-			playerTwoHands += 1
+	return nil
+}
+
+// endHand gives the hand value to the winner team, -1 being a draw, and
+// starts the next hand unless someone reached the target score
+func (g *Game) endHand(winner int) error {
+	g.hand().wonTeam = winner
+	if winner == -1 {
+		g.emit(HandWon{Team: -1})
+	} else {
+		points := g.rules.Stakes[g.hand().stake]
+		g.emit(HandWon{Team: winner, Points: points})
+		if err := g.addPoints(winner, points); err != nil || !g.running {
+			return err
 		}
 	}
+	g.hands = append(g.hands, newHand())
+	return g.startHand()
+}
 
-	if playerOneHands == 12 || playerTwoHands == 12 {
-		g.running = false
+// addPoints adds points to the team score and ends the game if it reached the
+// target score
+func (g *Game) addPoints(team, points int) error {
+	g.score[team] += points
+	if g.score[team] < g.rules.TargetScore {
+		return nil
+	}
+	g.running = false
+	g.emit(GameOver{Team: team})
+	if g.onGameOver != nil {
+		return g.onGameOver(team)
 	}
-
 	return nil
 }
 
@@ -302,9 +730,9 @@ func (g *Game) Play(player *Player, card Card) error {
 // 1 if card1 weight is greater than card2
 // 2 if card2 weight is greater than card1
 // 0 if they are equal
-func (h *Hand) compareCards(card1, card2 Card) int {
-	deckWeightOne := h.deckWeights[card1]
-	deckWeightTwo := h.deckWeights[card2]
+func (h *Hand) compareCards(card1, card2 playedCard) int {
+	deckWeightOne := h.weight(card1)
+	deckWeightTwo := h.weight(card2)
 	if deckWeightOne > deckWeightTwo {
 		return 1
 	}
@@ -314,10 +742,38 @@ func (h *Hand) compareCards(card1, card2 Card) int {
 	return 0
 }
 
-func (h *Hand) playCard(player *Player, card Card) {
+// nextSeat returns the position of the player after the given one in turn order
+func (h *Hand) nextSeat(position int) int {
+	for i, seat := range h.seats {
+		if seat == position {
+			return h.seats[(i+1)%len(h.seats)]
+		}
+	}
+	return h.seats[0]
+}
+
+// inHand returns true if the player in the position was dealt into the hand
+func (h *Hand) inHand(position int) bool {
+	for _, seat := range h.seats {
+		if seat == position {
+			return true
+		}
+	}
+	return false
+}
+
+// weight returns the weight of a played card, face down cards are the lowest
+func (h *Hand) weight(card playedCard) int {
+	if card.faceDown {
+		return 0
+	}
+	return h.deckWeights[card.card]
+}
+
+func (h *Hand) playCard(player *Player, card playedCard) {
 	// remove card from player
 	for i, c := range player.cards {
-		if c == card {
+		if c == card.card {
 			player.cards = append(player.cards[:i], player.cards[i+1:]...)
 			break
 		}
@@ -337,6 +793,33 @@ func (p *Player) Cards() []Card {
 	return p.cards
 }
 
+// CardsFor returns the cards the player can see in their own hand, during a
+// mão de ferro they are all shown as CardBack
+func (g *Game) CardsFor(player *Player) []Card {
+	cards := make([]Card, len(player.cards))
+	for i, c := range player.cards {
+		if g.hand().maoDeFerro {
+			c = CardBack
+		}
+		cards[i] = c
+	}
+	return cards
+}
+
+// PileFor returns the cards played in the current hand as seen by the player,
+// cards played face down by the other players are shown as CardBack
+func (g *Game) PileFor(player *Player) []Card {
+	position := g.position(player)
+	pile := make([]Card, len(g.hand().pile))
+	for i, c := range g.hand().pile {
+		pile[i] = c.card
+		if c.faceDown && c.position != position {
+			pile[i] = CardBack
+		}
+	}
+	return pile
+}
+
 func (p *Player) Name() string {
 	return p.name
 }
@@ -345,34 +828,146 @@ func (p *Player) ID() string {
 	return p.id
 }
 
+// LastPoint returns the player who won the last round, nil if it was a draw
 func (g *Game) LastPoint() *Player {
+	h, round := g.lastRound()
+	if h == nil || h.roundWinners[round] == -1 {
+		return nil
+	}
+	return g.players[h.roundWinners[round]]
+}
+
+// LastPointTeam returns the team that won the last round, -1 if it was a draw
+func (g *Game) LastPointTeam() int {
+	h, round := g.lastRound()
+	if h == nil {
+		return -1
+	}
+	return h.points[round]
+}
+
+// lastRound returns the hand and index of the last finished round
+func (g *Game) lastRound() (*Hand, uint) {
 	if g.hand().round == 0 {
 		if len(g.hands) == 1 {
-			return nil
+			return nil, 0
 		}
 		previousHand := g.hands[len(g.hands)-2]
-		if previousHand.points[previousHand.round-1] == -1 {
-			return nil
+		if previousHand.round == 0 {
+			return nil, 0
 		}
-		return g.players[previousHand.points[previousHand.round-1]]
+		return previousHand, previousHand.round - 1
 	}
-	if g.hand().points[g.hand().round-1] == -1 {
-		return nil
-	}
-	return g.players[g.hand().points[g.hand().round-1]]
+	return g.hand(), g.hand().round - 1
 }
 
+// Winner returns the first player of the team that won the last hand, nil if
+// it was a draw
 func (g *Game) Winner() *Player {
-	if g.hand().wonPosition == -1 {
+	team := g.WinnerTeam()
+	if team == -1 {
 		return nil
 	}
-	return g.players[g.hand().wonPosition]
+	return g.players[team]
+}
+
+// Winners returns every player of the team that won the last hand
+func (g *Game) Winners() []*Player {
+	return g.TeamPlayers(g.WinnerTeam())
+}
+
+// WinnerTeam returns the team that won the last hand, -1 if it was a draw
+func (g *Game) WinnerTeam() int {
+	h := g.hand()
+	if g.running {
+		if len(g.hands) == 1 {
+			return -1
+		}
+		h = g.hands[len(g.hands)-2]
+	}
+	return h.wonTeam
+}
+
+// Team returns the team of the player, players in even positions are team 0
+// and players in odd positions are team 1
+func (g *Game) Team(player *Player) int {
+	position := g.position(player)
+	if position == -1 {
+		return -1
+	}
+	return g.team(position)
+}
+
+// Dealer returns the player who dealt the current hand
+func (g *Game) Dealer() *Player {
+	return g.players[g.hand().dealer]
+}
+
+// Leader returns the player who leads the current hand (the "mão"), the first
+// player after the dealer
+func (g *Game) Leader() *Player {
+	return g.players[g.hand().seats[0]]
+}
+
+// HandPlayers returns the players dealt into the current hand, in turn order
+func (g *Game) HandPlayers() []*Player {
+	players := make([]*Player, len(g.hand().seats))
+	for i, seat := range g.hand().seats {
+		players[i] = g.players[seat]
+	}
+	return players
+}
+
+// TeamPlayers returns the players of the team in position order
+func (g *Game) TeamPlayers(team int) []*Player {
+	players := make([]*Player, 0)
+	for i, p := range g.players {
+		if team != -1 && g.team(i) == team {
+			players = append(players, p)
+		}
+	}
+	return players
+}
+
+func (g *Game) team(position int) int {
+	return position % 2
 }
 
 func (g *Game) Running() bool {
 	return g.running
 }
 
+// Scores returns the points of each team
+func (g *Game) Scores() []int {
+	score := make([]int, len(g.score))
+	copy(score, g.score)
+	return score
+}
+
+// HandValue returns how many points the current hand is worth
+func (g *Game) HandValue() int {
+	return g.rules.Stakes[g.hand().stake]
+}
+
+func (g *Game) position(player *Player) int {
+	for i, p := range g.players {
+		if p != nil && p.id == player.id {
+			return i
+		}
+	}
+	return -1
+}
+
+// Manilha returns the card turned up in the current hand (vira or muestra),
+// empty when the manilhas are fixed. A single card can't describe the fixed
+// set, so the manilhas themselves are reported by Manilhas in every mode
 func (g *Game) Manilha() Card {
 	return Card(g.hand().manilha)
 }
+
+// Manilhas returns the cards that beat the regular order in the current hand,
+// from the lowest to the highest. With ManilhaFixed it is the fixed set, such
+// as the manilhas velhas (4♣, 7♥, A♠ and 7♦)
+func (g *Game) Manilhas() []Card {
+	return slices.Clone(g.hand().manilhas)
+}
@@ -1,6 +1,11 @@
 package truco
 
-import "testing"
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+)
 
 func TestNewGame(t *testing.T) {
 	g, err := NewGame()
@@ -95,48 +100,35 @@ func TestRemovePlayer(t *testing.T) {
 }
 
 func TestSetManilha(t *testing.T) {
-	g, err := defaultGame(true)
-	if err != nil {
-		t.Error("failed to create game: " + err.Error())
-	}
+	g := startedGame(t)
 
-	if err := g.Start(); err != nil {
-		t.Error("failed to start game: " + err.Error())
-	}
-
-	if g.manilha != string(g.deck[0]) {
+	if g.hand().manilha != g.hand().deck[0] {
 		t.Error("manilha should be the same as the first card of the deck")
 	}
 
-	if g.manilha != string(ThreeHearts) {
-		t.Error("seed isn't working properly, expected manilha to be B3, instead got: " + g.manilha)
+	if g.hand().manilha != ThreeHearts {
+		t.Error("seed isn't working properly, expected manilha to be B3, instead got: " + string(g.hand().manilha))
 	}
 
-	// A2 A4 A7 CB
-	if g.deckWeights[ThreeClubs] != 20 {
-		t.Errorf("expected three clubs weight to be 20, instead got: %d", g.deckWeights[ThreeClubs])
+	// vira is a three, so the fours are the manilhas
+	if g.hand().deckWeights[FourClubs] != 11 {
+		t.Errorf("expected four clubs weight to be 11, instead got: %d", g.hand().deckWeights[FourClubs])
 	}
-	if g.deckWeights[ThreeDiamonds] != 21 {
-		t.Errorf("expected three diamonds weight to be 21, instead got: %d", g.deckWeights[ThreeDiamonds])
+	if g.hand().deckWeights[FourDiamonds] != 12 {
+		t.Errorf("expected four diamonds weight to be 12, instead got: %d", g.hand().deckWeights[FourDiamonds])
 	}
-	if g.deckWeights[ThreeHearts] != 22 {
-		t.Errorf("expected three hearts weight to be 22, instead got: %d", g.deckWeights[ThreeHearts])
+	if g.hand().deckWeights[FourHearts] != 13 {
+		t.Errorf("expected four hearts weight to be 13, instead got: %d", g.hand().deckWeights[FourHearts])
 	}
-	if g.deckWeights[ThreeSpades] != 23 {
-		t.Errorf("expected three spades weight to be 23, instead got: %d", g.deckWeights[ThreeSpades])
+	if g.hand().deckWeights[FourSpades] != 14 {
+		t.Errorf("expected four spades weight to be 14, instead got: %d", g.hand().deckWeights[FourSpades])
 	}
 }
 
 func TestDrawCards(t *testing.T) {
-	g, err := defaultGame(true)
-	if err != nil {
-		t.Error("failed to create game: " + err.Error())
-	}
-	if err := g.Start(); err != nil {
-		t.Error("failed to start game: " + err.Error())
-	}
-	if g.cardPointer != 7 {
-		t.Errorf("card pointer is at wrong location, expected 7, instead got: %d", g.cardPointer)
+	g := startedGame(t)
+	if g.hand().deckPosition != 7 {
+		t.Errorf("card pointer is at wrong location, expected 7, instead got: %d", g.hand().deckPosition)
 	}
 
 	p1 := g.players[0]
@@ -177,18 +169,12 @@ func TestHasCard(t *testing.T) {
 }
 
 func TestPlay(t *testing.T) {
-	g, err := defaultGame(true)
-	if err != nil {
-		t.Error("failed to create game: " + err.Error())
-	}
-	if err := g.Start(); err != nil {
-		t.Error("failed to start game: " + err.Error())
-	}
+	g := startedGame(t)
 	p1 := g.players[0]
-	if err := g.Play(p1, QueenSpades); err != nil {
+	if _, err := g.Play(p1, QueenSpades); err != nil {
 		t.Error("failed to play card: " + err.Error())
 	}
-	if err := g.Play(p1, QueenSpades); err != nil {
+	if _, err := g.Play(p1, QueenSpades); err != nil {
 		if err != ErrNotPlayerTurn {
 			t.Error("error should have been not player turn, instead got: " + err.Error())
 		}
@@ -196,49 +182,315 @@ func TestPlay(t *testing.T) {
 }
 
 func TestPlayCard(t *testing.T) {
-	g, err := defaultGame(true)
-	if err != nil {
-		t.Error("failed to create game: " + err.Error())
-	}
-	if err := g.Start(); err != nil {
-		t.Error("failed to start game: " + err.Error())
-	}
+	g := startedGame(t)
 	p1 := g.players[0]
-	g.playCard(p1, ThreeDiamonds)
+	g.hand().playCard(p1, playedCard{card: ThreeDiamonds, position: 0})
 	if len(p1.cards) != 2 {
 		t.Error("player 1 should have 2 cards")
 	}
-	if len(g.pile) != 1 {
+	if len(g.hand().pile) != 1 {
 		t.Error("pile should have 1 card")
 	}
-	if g.pile[0][0] != ThreeDiamonds {
+	if g.hand().pile[0].card != ThreeDiamonds {
 		t.Error("wrong card in pile")
 	}
 }
 
-func defaultGame(seed bool) (*Game, error) {
-	g, err := NewGame()
+// startedGame returns a started game with the options and every seat taken,
+// seeded so the same cards are always dealt
+func startedGame(t *testing.T, opts ...Option) *Game {
+	g, err := NewGame(append([]Option{WithSeed(123, 456)}, opts...)...)
 	if err != nil {
-		return nil, err
+		t.Fatal("failed to create game: " + err.Error())
 	}
-	p1, err := NewPlayer("player 1")
+	addPlayers(t, g)
+	if _, err := g.Start(); err != nil {
+		t.Fatal("failed to start game: " + err.Error())
+	}
+	return g
+}
+
+// addPlayers takes every free seat of the game, for tests that need to change
+// the game before it starts
+func addPlayers(t *testing.T, g *Game) {
+	for i := len(g.players); i < g.maxPlayers; i++ {
+		p, err := NewPlayer(fmt.Sprintf("player %d", i+1))
+		if err != nil {
+			t.Fatal("failed to create player: " + err.Error())
+		}
+		if err := g.AddPlayer(p); err != nil {
+			t.Fatal("failed to add player: " + err.Error())
+		}
+	}
+}
+
+func TestPlayFaceDown(t *testing.T) {
+	g := startedGame(t)
+	p1 := g.players[0]
+	p2 := g.players[1]
+
+	if _, err := g.PlayFaceDown(p1, QueenSpades); err != ErrFaceDownFirstRound {
+		t.Errorf("expected error ErrFaceDownFirstRound, instead got: %v", err)
+	}
+	if _, err := g.Play(p1, QueenSpades); err != nil {
+		t.Error("failed to play card: " + err.Error())
+	}
+	if _, err := g.Play(p2, SevenClubs); err != nil {
+		t.Error("failed to play card: " + err.Error())
+	}
+	// queen hearts would beat the seven, but face down it can't win
+	if _, err := g.PlayFaceDown(p1, QueenHearts); err != nil {
+		t.Error("failed to play card face down: " + err.Error())
+	}
+	if _, err := g.Play(p2, SevenClubs); err != ErrPlayerDoesNotHaveCard {
+		t.Errorf("expected error ErrPlayerDoesNotHaveCard, instead got: %v", err)
+	}
+	if g.PileFor(p2)[2] != CardBack {
+		t.Error("face down card should be hidden from player 2, instead got: " + string(g.PileFor(p2)[2]))
+	}
+	if g.PileFor(p1)[2] != QueenHearts {
+		t.Error("face down card should be visible to player 1, instead got: " + string(g.PileFor(p1)[2]))
+	}
+	if _, err := g.Play(p2, AceSpades); err != nil {
+		t.Error("failed to play card: " + err.Error())
+	}
+	if g.hand().points[1] != 1 {
+		t.Errorf("player 2 should have won the second round, instead got: %d", g.hand().points[1])
+	}
+}
+
+func TestTeamGame(t *testing.T) {
+	g := startedGame(t, WithMaxPlayers(4))
+	if err := g.SetMaxPlayers(2); err != ErrGameRunning {
+		t.Errorf("expected error ErrGameRunning, instead got: %v", err)
+	}
+	for i, p := range g.players {
+		if len(p.cards) != 3 {
+			t.Errorf("player %d should have 3 cards, instead got: %d", i+1, len(p.cards))
+		}
+	}
+	if g.Team(g.players[2]) != 0 || g.Team(g.players[3]) != 1 {
+		t.Error("players 1 and 3 should be team 0, players 2 and 4 team 1")
+	}
+
+	rounds := [][]Card{
+		// player 4 wins with the manilha
+		{ThreeDiamonds, AceSpades, FiveClubs, FourHearts},
+		// player 4 starts and wins again, which ends the hand
+		{JackSpades, QueenSpades, SevenClubs, SevenDiamonds},
+	}
+	leaders := []int{0, 3}
+	for r, cards := range rounds {
+		for i, card := range cards {
+			p := g.players[(leaders[r]+i)%4]
+			if g.CurrentPlayer() != p {
+				t.Fatalf("round %d: expected %s to play, instead got: %s", r, p.Name(), g.CurrentPlayer().Name())
+			}
+			if _, err := g.Play(p, card); err != nil {
+				t.Fatalf("round %d: failed to play %s: %s", r, card, err.Error())
+			}
+		}
+	}
+	if g.WinnerTeam() != 1 {
+		t.Errorf("expected team 1 to win the hand, instead got: %d", g.WinnerTeam())
+	}
+	if len(g.Winners()) != 2 || g.Winners()[1] != g.players[3] {
+		t.Error("winners should be players 2 and 4")
+	}
+	if g.LastPoint() != g.players[3] || g.LastPointTeam() != 1 {
+		t.Error("player 4 should have won the last round")
+	}
+	if g.Scores()[0] != 0 || g.Scores()[1] != 1 {
+		t.Errorf("expected score to be [0 1], instead got: %v", g.Scores())
+	}
+}
+
+func TestSetMaxPlayers(t *testing.T) {
+	g, err := NewGame()
 	if err != nil {
-		return nil, err
+		t.Fatal("failed to create game: " + err.Error())
 	}
-	p2, err := NewPlayer("player 2")
+	addPlayers(t, g)
+	if err := g.SetMaxPlayers(3); err != ErrInvalidPlayerCount {
+		t.Errorf("expected error ErrInvalidPlayerCount, instead got: %v", err)
+	}
+	if err := g.SetMaxPlayers(4); err != nil {
+		t.Error("failed to set max players: " + err.Error())
+	}
+	if _, err := g.Start(); err != ErrNotEnoughPlayers {
+		t.Errorf("expected error ErrNotEnoughPlayers, instead got: %v", err)
+	}
+}
+
+func TestSixPlayerGame(t *testing.T) {
+	g := startedGame(t, WithMaxPlayers(6))
+	for i, p := range g.players {
+		if len(p.cards) != 3 {
+			t.Errorf("player %d should have 3 cards, instead got: %d", i+1, len(p.cards))
+		}
+		if g.Team(p) != i%2 {
+			t.Errorf("player %d should be on team %d", i+1, i%2)
+		}
+	}
+	for i := 0; i < 6; i++ {
+		p := g.players[i]
+		if g.CurrentPlayer() != p {
+			t.Fatalf("expected %s to play, instead got: %s", p.Name(), g.CurrentPlayer().Name())
+		}
+		if _, err := g.Play(p, p.cards[0]); err != nil {
+			t.Fatal("failed to play card: " + err.Error())
+		}
+	}
+	if g.hand().round != 1 {
+		t.Error("round should be over after all 6 players played")
+	}
+}
+
+func TestPeRotation(t *testing.T) {
+	g := startedGame(t, WithMaxPlayers(6), WithPeRotation(true))
+
+	// full table, pé a pé with 1 and 2, full table, pé a pé with 3 and 4,
+	// always starting after the dealer
+	expected := [][]int{{0, 1, 2, 3, 4, 5}, {1, 0}, {2, 3, 4, 5, 0, 1}, {3, 2}}
+	for hand, seats := range expected {
+		players := g.HandPlayers()
+		if len(players) != len(seats) {
+			t.Fatalf("hand %d: expected %d players, instead got: %d", hand, len(seats), len(players))
+		}
+		for i, seat := range seats {
+			if players[i] != g.players[seat] {
+				t.Errorf("hand %d: expected %s to be in the hand", hand, g.players[seat].Name())
+			}
+		}
+		for i, p := range g.players {
+			dealt := g.hand().inHand(i)
+			if dealt && len(p.cards) != 3 || !dealt && len(p.cards) != 0 {
+				t.Errorf("hand %d: %s has %d cards", hand, p.Name(), len(p.cards))
+			}
+		}
+		if len(seats) == 2 {
+			if _, err := g.Truco(g.players[4]); err != ErrNotInHand {
+				t.Errorf("hand %d: expected error ErrNotInHand, instead got: %v", hand, err)
+			}
+		}
+		if _, err := g.Fold(g.CurrentPlayer()); err != nil {
+			t.Fatal("failed to fold: " + err.Error())
+		}
+	}
+}
+
+func TestDealerRotation(t *testing.T) {
+	g, err := NewGame(WithMaxPlayers(4), WithSeed(123, 456))
 	if err != nil {
-		return nil, err
+		t.Fatal("failed to create game: " + err.Error())
 	}
+	addPlayers(t, g)
+	if err := g.SetDealer(g.players[1]); err != nil {
+		t.Fatal("failed to set dealer: " + err.Error())
+	}
+	if _, err := g.Start(); err != nil {
+		t.Fatal("failed to start game: " + err.Error())
+	}
+	for hand := 0; hand < 6; hand++ {
+		dealer := (1 + hand) % 4
+		leader := (dealer + 1) % 4
+		if g.Dealer() != g.players[dealer] {
+			t.Errorf("hand %d: expected %s to deal, instead got: %s", hand, g.players[dealer].Name(), g.Dealer().Name())
+		}
+		if g.Leader() != g.players[leader] || g.CurrentPlayer() != g.players[leader] {
+			t.Errorf("hand %d: expected %s to lead, instead got: %s", hand, g.players[leader].Name(), g.Leader().Name())
+		}
+		if _, err := g.Fold(g.CurrentPlayer()); err != nil {
+			t.Fatal("failed to fold: " + err.Error())
+		}
+	}
+}
 
-	if err := g.AddPlayer(p1); err != nil {
-		return nil, err
+func TestDeckComposition(t *testing.T) {
+	if len(CleanDeck().Cards()) != 40 || len(DirtyDeck().Cards()) != 52 {
+		t.Error("clean deck should have 40 cards and dirty deck 52")
+	}
+	if !slices.Equal(CleanDeck().Cards(), DefaultDeck()) {
+		t.Error("clean deck should have the cards of the default deck in the same order")
+	}
+	if !maps.Equal(CleanDeck().Weights(), DefaultDeckWeights()) {
+		t.Error("clean deck weights should be the default weights")
 	}
+	dirty := DirtyDeck().Weights()
+	if dirty[TenSpades] != 7 || dirty[QueenSpades] != 8 || dirty[ThreeClubs] != 13 {
+		t.Error("the 8, 9 and 10 should be between the 7 and the queen")
+	}
+	if TenHearts.Unicode() != "🂺" {
+		t.Error("wrong unicode card for BA, instead got: " + TenHearts.Unicode())
+	}
+}
 
-	if err := g.AddPlayer(p2); err != nil {
-		return nil, err
+// TestManilhaOrder checks that the manilha is the rank above the vira in the
+// strength order, not in card ID order where a 7 would be followed by jacks and
+// a queen by kings
+func TestManilhaOrder(t *testing.T) {
+	cases := []struct {
+		deck       Deck
+		vira, next string
+	}{
+		{CleanDeck(), Seven, Queen},
+		{CleanDeck(), Queen, Jack},
+		{CleanDeck(), Jack, King},
+		{CleanDeck(), Three, Four},
+		{DirtyDeck(), Seven, Eight},
+		{DirtyDeck(), Ten, Queen},
+	}
+	for _, c := range cases {
+		next, err := c.deck.nextRank(c.vira)
+		if err != nil {
+			t.Fatal("failed to get next rank: " + err.Error())
+		}
+		if next != c.next {
+			t.Errorf("expected %s after %s, instead got: %s", c.next, c.vira, next)
+		}
+	}
+	if _, err := CleanDeck().nextRank(Eight); err == nil {
+		t.Error("8 is not in the clean deck")
 	}
-	if seed {
-		g.Seed(123, 456)
+
+	h := newHand()
+	h.deck = []Card{SevenHearts}
+	if err := h.setManilha(CleanDeck(), DefaultManilhaSuits()); err != nil {
+		t.Fatal("failed to set manilha: " + err.Error())
+	}
+	expected := []Card{QueenClubs, QueenDiamonds, QueenHearts, QueenSpades}
+	if !slices.Equal(h.manilhas, expected) {
+		t.Errorf("expected manilhas %v, instead got: %v", expected, h.manilhas)
+	}
+	if h.deckWeights[QueenClubs] <= h.deckWeights[ThreeSpades] {
+		t.Error("queens should beat every other card with a 7 vira")
+	}
+}
+
+func TestShortDeck(t *testing.T) {
+	rules := Paulista.Rules()
+	rules.Deck = Deck{Ranks: []string{Ace, Two, Three}, Suits: []string{Spades, Hearts}}
+	rules.DeckWeights = rules.Deck.Weights()
+	g, err := NewGame(WithRules(rules), WithSeed(123, 456))
+	if err != nil {
+		t.Fatal("failed to create game: " + err.Error())
+	}
+	addPlayers(t, g)
+	if _, err := g.Start(); err != ErrDeckTooSmall {
+		t.Errorf("expected error ErrDeckTooSmall, instead got: %v", err)
+	}
+
+	rules.Deck.Suits = append(rules.Deck.Suits, Diamonds)
+	rules.DeckWeights = rules.Deck.Weights()
+	if err := g.SetRules(rules); err != nil {
+		t.Fatal("failed to set rules: " + err.Error())
+	}
+	if _, err := g.Start(); err != nil {
+		t.Fatal("failed to start game: " + err.Error())
+	}
+	for _, c := range append(g.players[0].cards, g.Manilha()) {
+		if !slices.Contains(rules.Deck.Cards(), c) {
+			t.Error("card is not in the deck: " + string(c))
+		}
 	}
-	return g, nil
 }
@@ -0,0 +1,140 @@
+package truco
+
+// Variant is a regional set of truco rules
+type Variant int
+
+const (
+	// Paulista turns up a vira and the next rank is the manilha, hands are
+	// worth 1, 3 (truco), 6, 9 and 12 points
+	Paulista Variant = iota
+	// Mineiro has fixed manilhas and no vira, hands are worth 2, 4 (truco),
+	// 8, 10 and 12 points
+	Mineiro
+	// Argentino, also played as Truco Gaúcho, uses the fixed Spanish card order
+	// and no vira. Hands are worth 1, 2 (truco), 3 (retruco) and 4 (vale
+	// cuatro) points, the game goes to 30 and has envido
+	Argentino
+	// Uruguaio plays like Argentino, but turns up a muestra card. The 2, 4, 5,
+	// 11 and 10 of the muestra suit are piezas, which beat every other card and
+	// have special envido and flor values
+	Uruguaio
+	// Valenciano is the Valencian Truc: the Spanish card order of Argentino,
+	// hands worth 1, 2 (truc), 3 (retruc) and 4 (quatre val) points and a game
+	// to 24. The envit is bet with envit, torne (a second envit) and falta
+	Valenciano
+)
+
+// MineiroManilhas returns the fixed manilhas of Truco Mineiro, also played in
+// Paulista as manilha velha, from the lowest to the highest: pica-fumo,
+// espadilha, copas and zap
+func MineiroManilhas() []Card {
+	return []Card{SevenDiamonds, AceSpades, SevenHearts, FourClubs}
+}
+
+// MineiroStakes returns the value of a hand for each betting level of Truco
+// Mineiro: no call, truco, seis, nove and doze
+func MineiroStakes() []int {
+	return []int{2, 4, 8, 10, 12}
+}
+
+// ArgentinoDeckWeights returns the fixed Spanish card order: ancho de espadas,
+// ancho de bastos, siete de espadas, siete de oros, threes, twos, the other
+// aces, kings (12), queens (11), jacks (10), the other sevens, sixes, fives and fours
+func ArgentinoDeckWeights() map[Card]int {
+	return map[Card]int{
+		FourSpades:    1,
+		FourHearts:    1,
+		FourDiamonds:  1,
+		FourClubs:     1,
+		FiveSpades:    2,
+		FiveHearts:    2,
+		FiveDiamonds:  2,
+		FiveClubs:     2,
+		SixSpades:     3,
+		SixHearts:     3,
+		SixDiamonds:   3,
+		SixClubs:      3,
+		SevenHearts:   4,
+		SevenClubs:    4,
+		JackSpades:    5,
+		JackHearts:    5,
+		JackDiamonds:  5,
+		JackClubs:     5,
+		QueenSpades:   6,
+		QueenHearts:   6,
+		QueenDiamonds: 6,
+		QueenClubs:    6,
+		KingSpades:    7,
+		KingHearts:    7,
+		KingDiamonds:  7,
+		KingClubs:     7,
+		AceHearts:     8,
+		AceDiamonds:   8,
+		TwoSpades:     9,
+		TwoHearts:     9,
+		TwoDiamonds:   9,
+		TwoClubs:      9,
+		ThreeSpades:   10,
+		ThreeHearts:   10,
+		ThreeDiamonds: 10,
+		ThreeClubs:    10,
+		SevenDiamonds: 11,
+		SevenSpades:   12,
+		AceClubs:      13,
+		AceSpades:     14,
+	}
+}
+
+// ArgentinoStakes returns the value of a hand for each betting level of
+// Truco Argentino: no call, truco, retruco and vale cuatro
+func ArgentinoStakes() []int {
+	return []int{1, 2, 3, 4}
+}
+
+// DefaultManilhaSuits returns the suits of the manilhas from the lowest to the
+// highest, the order used when the manilha comes from the vira
+func DefaultManilhaSuits() []string {
+	return []string{Clubs, Diamonds, Hearts, Spades}
+}
+
+// Rules returns the rule set of the variant
+func (v Variant) Rules() RuleSet {
+	rules := RuleSet{
+		Deck:         CleanDeck(),
+		DeckWeights:  DefaultDeckWeights(),
+		Manilha:      ManilhaVira,
+		ManilhaSuits: DefaultManilhaSuits(),
+		Stakes:       DefaultStakes(),
+		TargetScore:  12,
+		TieRule:      TieNobodyScores,
+		MaoDeOnze:    true,
+	}
+	switch v {
+	case Mineiro:
+		rules.Manilha = ManilhaFixed
+		rules.Manilhas = MineiroManilhas()
+		rules.Stakes = MineiroStakes()
+	case Argentino, Uruguaio:
+		// every round tied goes to the mano
+		rules.TieRule = TieFirstRound
+		rules.DeckWeights = ArgentinoDeckWeights()
+		rules.Manilha = ManilhaFixed
+		rules.Stakes = ArgentinoStakes()
+		rules.TargetScore = 30
+		rules.MaoDeOnze = false
+		rules.Envido = true
+		if v == Uruguaio {
+			rules.Manilha = ManilhaMuestra
+		}
+	case Valenciano:
+		rules.TieRule = TieFirstRound
+		rules.DeckWeights = ArgentinoDeckWeights()
+		rules.Manilha = ManilhaFixed
+		rules.Stakes = ArgentinoStakes()
+		rules.TargetScore = 24
+		rules.MaoDeOnze = false
+		rules.Envido = true
+		rules.EnvidoCalls = []EnvidoCall{Envido, FaltaEnvido}
+	}
+	return rules
+}
@@ -0,0 +1,116 @@
+package truco
+
+import "testing"
+
+func TestMineiro(t *testing.T) {
+	g := startedGame(t, WithVariant(Mineiro))
+	if err := g.SetVariant(Paulista); err != ErrGameRunning {
+		t.Errorf("expected error ErrGameRunning, instead got: %v", err)
+	}
+	p1 := g.players[0]
+	p2 := g.players[1]
+
+	if g.Manilha() != "" {
+		t.Error("there should be no vira in Truco Mineiro, instead got: " + string(g.Manilha()))
+	}
+	// without a vira the first card of the deck is dealt
+	if p1.cards[0] != ThreeHearts {
+		t.Error("wrong card for player, expected B3, instead got: " + string(p1.cards[0]))
+	}
+	if g.hand().deckWeights[FourClubs] != 14 || g.hand().deckWeights[SevenDiamonds] != 11 {
+		t.Error("zap and pica-fumo should be the highest and lowest manilhas")
+	}
+	if g.hand().deckWeights[FourHearts] != 1 {
+		t.Error("only the four of clubs should be a manilha")
+	}
+	if g.HandValue() != 2 {
+		t.Errorf("expected hand value to be 2, instead got: %d", g.HandValue())
+	}
+
+	if _, err := g.Play(p1, QueenHearts); err != nil {
+		t.Error("failed to play card: " + err.Error())
+	}
+	if _, err := g.Play(p2, AceSpades); err != nil {
+		t.Error("failed to play card: " + err.Error())
+	}
+	if g.LastPoint() != p2 {
+		t.Error("espadilha should win the round")
+	}
+	if _, err := g.Truco(p2); err != nil {
+		t.Error("failed to call truco: " + err.Error())
+	}
+	if _, err := g.Accept(p1); err != nil {
+		t.Error("failed to accept truco: " + err.Error())
+	}
+	if g.HandValue() != 4 {
+		t.Errorf("expected hand value to be 4, instead got: %d", g.HandValue())
+	}
+}
+
+func TestMineiroMaoDeDez(t *testing.T) {
+	g := startedGame(t, WithVariant(Mineiro), WithStartingScores(0, 10))
+	if g.MaoDeOnze() != g.players[1] {
+		t.Fatal("player 2 should be deciding the mão de dez")
+	}
+	if _, err := g.AcceptMaoDeOnze(g.players[1]); err != nil {
+		t.Error("failed to accept mão de dez: " + err.Error())
+	}
+	if g.HandValue() != 4 {
+		t.Errorf("expected hand value to be 4, instead got: %d", g.HandValue())
+	}
+}
+
+func TestValenciano(t *testing.T) {
+	g, err := NewGame(WithVariant(Valenciano), WithSeed(123, 456))
+	if err != nil {
+		t.Fatal("failed to create game: " + err.Error())
+	}
+	for _, name := range []string{"player 1", "player 2"} {
+		p, err := NewPlayer(name)
+		if err != nil {
+			t.Fatal("failed to create player: " + err.Error())
+		}
+		if err := g.AddPlayer(p); err != nil {
+			t.Fatal("failed to add player: " + err.Error())
+		}
+	}
+	if _, err := g.Start(); err != nil {
+		t.Fatal("failed to start game: " + err.Error())
+	}
+	p1 := g.players[0]
+	p2 := g.players[1]
+
+	if g.Manilha() != "" || g.hand().deckWeights[AceSpades] != 14 || g.hand().deckWeights[AceClubs] != 13 {
+		t.Error("the as d'espases and the as de bastos should be the highest cards")
+	}
+	// envit and torne
+	if _, err := g.CallEnvido(p1, Envido); err != nil {
+		t.Fatal("failed to call envit: " + err.Error())
+	}
+	if _, err := g.CallEnvido(p2, RealEnvido); err != ErrInvalidEnvidoCall {
+		t.Errorf("expected error ErrInvalidEnvidoCall, instead got: %v", err)
+	}
+	if _, err := g.CallEnvido(p2, Envido); err != nil {
+		t.Fatal("failed to call torne: " + err.Error())
+	}
+	if _, err := g.Refuse(p1); err != nil {
+		t.Fatal("failed to refuse torne: " + err.Error())
+	}
+	if g.Scores()[1] != 2 {
+		t.Errorf("expected player 2 to score the envit, instead got: %v", g.Scores())
+	}
+	if _, err := g.Truco(p1); err != nil {
+		t.Fatal("failed to call truc: " + err.Error())
+	}
+	if _, err := g.Accept(p2); err != nil {
+		t.Fatal("failed to accept truc: " + err.Error())
+	}
+	if g.HandValue() != 2 {
+		t.Errorf("expected hand value to be 2, instead got: %d", g.HandValue())
+	}
+
+	g.score[1] = 20
+	if g.faltaEnvido() != 4 {
+		t.Errorf("expected falta to be worth 4 points in a game to 24, instead got: %d", g.faltaEnvido())
+	}
+}
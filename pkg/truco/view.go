@@ -0,0 +1,130 @@
+package truco
+
+import "slices"
+
+// View is what a player can see of the game at one moment. It is a copy, it
+// doesn't change when the game goes on, and it never has the cards of the
+// other players or the cards left in the deck. Players are identified by
+// their position in the game
+type View struct {
+	// position of the player the view belongs to
+	Position int
+	Team     int
+	Running  bool
+	// points of each team
+	Scores []int
+	// cards in the player's hand, CardBack during a mão de ferro
+	Cards []Card
+	// number of cards in the hand of each player, by position
+	CardCounts []int
+	// cards played in the current hand, in order
+	Pile []PileCard
+	// card turned up in the hand (vira or muestra), empty when the manilhas
+	// are fixed
+	Manilha Card
+	// cards that beat the regular order, from the lowest to the highest
+	Manilhas []Card
+	Dealer   int
+	// player who will play the next card
+	CurrentPlayer int
+	// team that won each finished round of the hand, -1 = draw
+	Rounds []int
+	// points the hand is worth
+	HandValue int
+	// truco call waiting for an answer, nil if there is none
+	Truco *PendingTruco
+	// envido bet not settled yet, nil if there is none
+	Envido *PendingEnvido
+	// flor bet waiting for an answer, nil if there is none
+	Flor *PendingFlor
+	// team deciding the mão de onze, -1 = not a mão de onze
+	MaoDeOnze  int
+	MaoDeFerro bool
+	// the deck has to be cut before the cards are dealt
+	CutPending bool
+}
+
+// PileCard is a card on the table as seen by a player
+type PileCard struct {
+	// position of the player who played the card
+	Player int
+	// CardBack if it was played face down by another player
+	Card     Card
+	FaceDown bool
+}
+
+// PendingTruco is a truco call waiting for an answer
+type PendingTruco struct {
+	// position of the player who made the call
+	Player int
+	// value of the hand if the call is accepted
+	Value int
+}
+
+// PendingEnvido is an envido bet that wasn't settled yet
+type PendingEnvido struct {
+	// position of the player who made the last call
+	Player int
+	// calls made so far, in order
+	Calls []EnvidoCall
+	// the bet was accepted and the players are announcing their points
+	Accepted bool
+}
+
+// PendingFlor is a flor bet waiting for an answer
+type PendingFlor struct {
+	// position of the player who made the last call
+	Player int
+	// calls made so far, in order
+	Calls []FlorCall
+}
+
+// ViewFor returns what the player can see of the current hand
+func (g *Game) ViewFor(player *Player) (View, error) {
+	position := g.position(player)
+	if position == -1 {
+		return View{}, ErrPlayerNotFound
+	}
+	h := g.hand()
+	view := View{
+		Position:      position,
+		Team:          g.team(position),
+		Running:       g.running,
+		Scores:        g.Scores(),
+		Cards:         g.CardsFor(player),
+		CardCounts:    make([]int, len(g.players)),
+		Pile:          make([]PileCard, len(h.pile)),
+		Manilha:       h.manilha,
+		Manilhas:      slices.Clone(h.manilhas),
+		Dealer:        h.dealer,
+		CurrentPlayer: int(h.currentPlayer),
+		Rounds:        slices.Clone(h.points[:h.round]),
+		HandValue:     g.HandValue(),
+		MaoDeOnze:     h.maoDeOnze,
+		MaoDeFerro:    h.maoDeFerro,
+		CutPending:    h.phase == phaseCut,
+	}
+	for i, p := range g.players {
+		view.CardCounts[i] = len(p.cards)
+	}
+	for i, c := range h.pile {
+		view.Pile[i] = PileCard{Player: c.position, Card: c.card, FaceDown: c.faceDown}
+		if c.faceDown && c.position != position {
+			view.Pile[i].Card = CardBack
+		}
+	}
+	if h.call != nil {
+		view.Truco = &PendingTruco{Player: h.call.position, Value: g.rules.Stakes[h.call.stake]}
+	}
+	if h.envidoOpen() {
+		view.Envido = &PendingEnvido{
+			Player:   h.envido.position,
+			Calls:    slices.Clone(h.envido.calls),
+			Accepted: !h.envido.pending,
+		}
+	}
+	if h.florOpen() {
+		view.Flor = &PendingFlor{Player: h.flor.position, Calls: slices.Clone(h.flor.calls)}
+	}
+	return view, nil
+}
@@ -0,0 +1,91 @@
+package truco
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestViewFor(t *testing.T) {
+	g := startedGame(t)
+	stranger, err := NewPlayer("stranger")
+	if err != nil {
+		t.Fatal("failed to create player: " + err.Error())
+	}
+	if _, err := g.ViewFor(stranger); err != ErrPlayerNotFound {
+		t.Errorf("expected error ErrPlayerNotFound, instead got: %v", err)
+	}
+
+	// play the first round, then the second card face down and call truco
+	for range 2 {
+		if _, err := g.PlayPosition(g.CurrentPlayer(), 0); err != nil {
+			t.Fatal("failed to play card: " + err.Error())
+		}
+	}
+	if g.hand().round != 1 {
+		t.Fatal("first round should be over")
+	}
+	hidden := g.CurrentPlayer()
+	card := hidden.cards[0]
+	if _, err := g.PlayFaceDown(hidden, card); err != nil {
+		t.Fatal("failed to play face down: " + err.Error())
+	}
+	caller := g.CurrentPlayer()
+	if _, err := g.Truco(caller); err != nil {
+		t.Fatal("failed to call truco: " + err.Error())
+	}
+
+	for _, p := range g.players {
+		view, err := g.ViewFor(p)
+		if err != nil {
+			t.Fatal("failed to get view: " + err.Error())
+		}
+		if !slices.Equal(view.Cards, p.cards) {
+			t.Errorf("expected own cards %v, instead got: %v", p.cards, view.Cards)
+		}
+		for _, other := range g.players {
+			if other == p {
+				continue
+			}
+			for _, c := range other.cards {
+				if slices.Contains(view.Cards, c) {
+					t.Errorf("view should not have the cards of other players, found: %s", c)
+				}
+			}
+		}
+		if !slices.Equal(view.CardCounts, []int{1, 2}) && !slices.Equal(view.CardCounts, []int{2, 1}) {
+			t.Errorf("expected card counts of 1 and 2, instead got: %v", view.CardCounts)
+		}
+		if len(view.Pile) != 3 || !view.Pile[2].FaceDown {
+			t.Fatalf("expected 3 cards on the table with the last face down, instead got: %v", view.Pile)
+		}
+		expected := CardBack
+		if p == hidden {
+			expected = card
+		}
+		if view.Pile[2].Card != expected {
+			t.Errorf("expected face down card to be %s, instead got: %s", expected, view.Pile[2].Card)
+		}
+		if view.Truco == nil || view.Truco.Player != g.position(caller) || view.Truco.Value != 3 {
+			t.Errorf("expected pending truco worth 3 from the caller, instead got: %v", view.Truco)
+		}
+		if len(view.Rounds) != 1 || view.Manilha != g.Manilha() || !slices.Equal(view.Scores, g.score) {
+			t.Error("view should have the rounds, manilha and scores of the game")
+		}
+	}
+
+	view, err := g.ViewFor(caller)
+	if err != nil {
+		t.Fatal("failed to get view: " + err.Error())
+	}
+	view.Cards[0] = CardBack
+	view.Scores[0] = 10
+	if caller.cards[0] == CardBack || g.score[0] == 10 {
+		t.Error("changing the view should not change the game")
+	}
+	if _, err := g.Accept(g.players[g.position(caller)^1]); err != nil {
+		t.Fatal("failed to accept truco: " + err.Error())
+	}
+	if view.Truco == nil {
+		t.Error("view should not change when the game goes on")
+	}
+}